@@ -213,6 +213,9 @@ type Analyzer struct {
 	stats             *Metrics
 	errors            map[string][]Error // keys are file paths; values are the golang errors in those files
 	tests             bool
+	taintTests        bool
+	taintDiagnostics  bool
+	overrides         map[string]ruleOverride // keyed by rule ID
 	excludeGenerated  bool
 	showIgnored       bool
 	trackSuppressions bool
@@ -230,9 +233,21 @@ func NewAnalyzer(conf Config, tests bool, excludeGenerated bool, trackSuppressio
 	if enabled, err := conf.IsGlobalEnabled(ShowIgnored); err == nil {
 		showIgnored = enabled
 	}
+	taintTests := false
+	if enabled, err := conf.IsGlobalEnabled(TaintTests); err == nil {
+		taintTests = enabled
+	}
+	taintDiagnostics := false
+	if enabled, err := conf.IsGlobalEnabled(TaintDiagnostics); err == nil {
+		taintDiagnostics = enabled
+	}
 	if logger == nil {
 		logger = log.New(os.Stderr, "[gosec]", log.LstdFlags)
 	}
+	overrides, err := newRuleOverrides(conf)
+	if err != nil {
+		logger.Printf("Ignoring rule overrides: %v", err)
+	}
 	return &Analyzer{
 		ignoreNosec:       ignoreNoSec,
 		showIgnored:       showIgnored,
@@ -244,6 +259,9 @@ func NewAnalyzer(conf Config, tests bool, excludeGenerated bool, trackSuppressio
 		stats:             &Metrics{},
 		errors:            make(map[string][]Error),
 		tests:             tests,
+		taintTests:        taintTests,
+		taintDiagnostics:  taintDiagnostics,
+		overrides:         overrides,
 		concurrency:       concurrency,
 		excludeGenerated:  excludeGenerated,
 		trackSuppressions: trackSuppressions,
@@ -254,6 +272,11 @@ func NewAnalyzer(conf Config, tests bool, excludeGenerated bool, trackSuppressio
 // SetConfig updates the analyzer configuration
 func (gosec *Analyzer) SetConfig(conf Config) {
 	gosec.config = conf
+	overrides, err := newRuleOverrides(conf)
+	if err != nil {
+		gosec.logger.Printf("Ignoring rule overrides: %v", err)
+	}
+	gosec.overrides = overrides
 }
 
 // Config returns the current configuration
@@ -441,7 +464,9 @@ func (gosec *Analyzer) load(pkgPath string, buildTags []string) ([]*packages.Pac
 		packageFiles = append(packageFiles, path.Join(pkgPath, filename))
 	}
 
-	if gosec.tests {
+	// Test files are also loaded for the taint analysis rules alone
+	loadTests := gosec.tests || gosec.taintTests
+	if loadTests {
 		testsFiles := make([]string, 0)
 		testsFiles = append(testsFiles, basePackage.TestGoFiles...)
 		testsFiles = append(testsFiles, basePackage.XTestGoFiles...)
@@ -455,7 +480,7 @@ func (gosec *Analyzer) load(pkgPath string, buildTags []string) ([]*packages.Pac
 	conf := &packages.Config{
 		Mode:       LoadMode,
 		BuildFlags: CLIBuildTags(buildTags),
-		Tests:      gosec.tests,
+		Tests:      loadTests,
 	}
 	if modRoot := FindModuleRoot(abspath); modRoot != "" {
 		conf.Dir = modRoot
@@ -483,6 +508,7 @@ func (gosec *Analyzer) checkRules(pkg *packages.Package) ([]*issue.Issue, *Metri
 	gosec.logger.Println("Checking package:", pkg.Name)
 	stats := &Metrics{}
 	allIgnores := newIgnores()
+	testsOnly := gosec.checksTestsOnly(pkg)
 
 	callCache := callCachePool.Get().(map[ast.Node]callInfo)
 	defer func() {
@@ -544,11 +570,16 @@ func (gosec *Analyzer) checkRules(pkg *packages.Package) ([]*issue.Issue, *Metri
 
 		visitor.context = ctx
 		visitor.updateIgnores()
-		if len(visitor.activeRuleset().Rules) > 0 {
-			ast.Walk(visitor, file)
+		// Test files loaded for the taint analysis rules alone keep their
+		// suppressions, but are not checked by the other rules. The other
+		// files of their package are checked in the package itself.
+		if gosec.tests || (!isTestFile(checkedFile) && !testsOnly) {
+			if len(visitor.activeRuleset().Rules) > 0 {
+				ast.Walk(visitor, file)
+			}
+			stats.NumFiles++
+			stats.NumLines += pkg.Fset.File(file.Pos()).LineCount()
 		}
-		stats.NumFiles++
-		stats.NumLines += pkg.Fset.File(file.Pos()).LineCount()
 
 		// Collect ignores
 		if ctx.Ignores != nil {
@@ -617,7 +648,11 @@ func (gosec *Analyzer) checkAnalyzersWithSSA(pkg *packages.Package, ssaResult *b
 	runner := errgroup.Group{}
 	runner.SetLimit(max(gosec.concurrency, 1))
 
+	testsOnly := gosec.checksTestsOnly(pkg)
 	for index, analyzer := range gosec.analyzerSet.Analyzers {
+		if testsOnly && !analyzers.IsTaintRule(analyzer.Name) {
+			continue
+		}
 		runner.Go(func() error {
 			pass := &analysis.Pass{
 				Analyzer:     analyzer,
@@ -669,6 +704,15 @@ func (gosec *Analyzer) checkAnalyzersWithSSA(pkg *packages.Package, ssaResult *b
 					continue
 				}
 			}
+			if !gosec.tests && isTestFile(iss.File) && !analyzers.IsTaintRule(iss.RuleID) {
+				continue
+			}
+			if testsOnly && !isTestFile(iss.File) {
+				continue
+			}
+			if !gosec.taintDiagnostics {
+				iss.Diagnostics = nil
+			}
 
 			// issue filtering logic
 			issues = gosec.updateIssues(iss, issues, stats, allIgnores)
@@ -677,6 +721,22 @@ func (gosec *Analyzer) checkAnalyzersWithSSA(pkg *packages.Package, ssaResult *b
 	return issues, stats
 }
 
+// checksTestsOnly reports whether only the test files of pkg are checked:
+// pkg is a test variant loaded for the taint analysis rules alone, such as
+// "p [p.test]", whose other files are checked in the package itself. Files
+// loaded by path leave ForTest empty, so the variant is told by its ID.
+func (gosec *Analyzer) checksTestsOnly(pkg *packages.Package) bool {
+	if !gosec.taintTests || gosec.tests {
+		return false
+	}
+	return pkg.ForTest != "" || strings.HasSuffix(pkg.ID, ".test]") || strings.HasSuffix(pkg.PkgPath, ".test")
+}
+
+// isTestFile reports whether the file at path is a Go test file.
+func isTestFile(path string) bool {
+	return strings.HasSuffix(path, "_test.go")
+}
+
 func (gosec *Analyzer) generatedFiles(pkg *packages.Package) map[string]bool {
 	generatedFiles := map[string]bool{}
 	for _, file := range pkg.Syntax {
@@ -1021,10 +1081,59 @@ func (v *astVisitor) ignore(n ast.Node) (map[string]issue.SuppressionInfo, *ast.
 	return nil, nil
 }
 
+// ruleOverride is the parsed form of a RuleOverride
+type ruleOverride struct {
+	severity      *issue.Score
+	minConfidence issue.Score
+}
+
+// newRuleOverrides parses the per-rule overrides of the configuration.
+func newRuleOverrides(conf Config) (map[string]ruleOverride, error) {
+	configured, err := conf.GetRuleOverrides()
+	if err != nil || len(configured) == 0 {
+		return nil, err
+	}
+	overrides := make(map[string]ruleOverride, len(configured))
+	for id, o := range configured {
+		var override ruleOverride
+		if o.Severity != "" {
+			severity, _ := parseScore(o.Severity)
+			override.severity = &severity
+		}
+		if o.MinConfidence != "" {
+			override.minConfidence, _ = parseScore(o.MinConfidence)
+		}
+		overrides[id] = override
+	}
+	return overrides, nil
+}
+
+// applyOverride applies the configured override of the issue's rule, and
+// reports whether the issue should still be reported.
+func (gosec *Analyzer) applyOverride(iss *issue.Issue) bool {
+	override, ok := gosec.overrides[iss.RuleID]
+	if !ok {
+		return true
+	}
+	if iss.Confidence < override.minConfidence {
+		return false
+	}
+	if override.severity != nil {
+		iss.Severity = *override.severity
+	}
+	return true
+}
+
 // updateIssues updates the issues list with the given issue, handling suppressions.
 func (gosec *Analyzer) updateIssues(issue *issue.Issue, issues []*issue.Issue, stats *Metrics, allIgnores ignores) []*issue.Issue {
-	if issue != nil {
+	if issue != nil && gosec.applyOverride(issue) {
 		suppressions, ignored := getSuppressions(allIgnores, issue.File, issue.Line, issue.RuleID, gosec.ruleset, gosec.analyzerSet)
+		// A taint issue may also be suppressed where the reviewed input is
+		// read, far from the sink. Other lines of its flow, such as a shared
+		// helper, are also on the flows of other callers.
+		if source, ok := issue.SourceStep(); ok && !ignored {
+			suppressions, ignored = getSuppressions(allIgnores, source.File, source.Line, issue.RuleID, gosec.ruleset, gosec.analyzerSet)
+		}
 		if gosec.showIgnored {
 			issue.NoSec = ignored
 		}
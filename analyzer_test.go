@@ -28,6 +28,7 @@ import (
 
 	"github.com/securego/gosec/v2"
 	"github.com/securego/gosec/v2/analyzers"
+	"github.com/securego/gosec/v2/issue"
 	"github.com/securego/gosec/v2/rules"
 	"github.com/securego/gosec/v2/testutils"
 )
@@ -2349,6 +2350,257 @@ func main() {
 		})
 	})
 
+	Context("when analyzing test files for the taint rules", func() {
+		const mainFile = `
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+)
+
+func handler(db *sql.DB, r *http.Request) {
+	db.Query(fmt.Sprintf("SELECT * FROM users WHERE name = '%s'", r.FormValue("name")))
+	n, _ := strconv.Atoi(r.FormValue("n"))
+	_ = int8(n)
+	os.Remove("tmp")
+}
+
+func main() {}
+`
+		const testFile = `
+package main
+
+import (
+	"database/sql"
+	"os"
+	"testing"
+)
+
+func TestHandler(t *testing.T) {
+	db, _ := sql.Open("sqlite", ":memory:")
+	name, _ := os.ReadFile("testdata/name.txt")
+	db.Query("SELECT * FROM users WHERE name = '" + string(name) + "'")
+}
+`
+		run := func(taintTests bool) []*issue.Issue {
+			config := gosec.NewConfig()
+			if taintTests {
+				config.SetGlobal(gosec.TaintTests, "enabled")
+			}
+			customAnalyzer := gosec.NewAnalyzer(config, false, false, false, 1, logger)
+			customAnalyzer.LoadRules(rules.Generate(false).RulesInfo())
+			customAnalyzer.LoadAnalyzers(analyzers.Generate(false).AnalyzersInfo())
+			pkg := testutils.NewTestPackage()
+			defer pkg.Close()
+			pkg.AddFile("main.go", mainFile)
+			pkg.AddFile("main_test.go", testFile)
+			err := pkg.Build()
+			Expect(err).ShouldNot(HaveOccurred())
+			err = customAnalyzer.Process(buildTags, pkg.Path)
+			Expect(err).ShouldNot(HaveOccurred())
+			issues, _, _ := customAnalyzer.Report()
+			return issues
+		}
+
+		It("should report the issues of the other files once", func() {
+			regular := run(false)
+			Expect(regular).ShouldNot(BeEmpty())
+			withTests := run(true)
+
+			var testIssues []*issue.Issue
+			seen := make(map[string]bool)
+			for _, iss := range withTests {
+				key := fmt.Sprintf("%s %s:%s:%s", iss.RuleID, iss.File, iss.Line, iss.Col)
+				Expect(seen).ShouldNot(HaveKey(key))
+				seen[key] = true
+				if strings.HasSuffix(iss.File, "_test.go") {
+					testIssues = append(testIssues, iss)
+				}
+			}
+			Expect(testIssues).Should(HaveLen(1))
+			Expect(testIssues[0].RuleID).Should(Equal("G701"))
+			Expect(withTests).Should(HaveLen(len(regular) + 1))
+		})
+	})
+
+	Context("when suppressing taint analysis issues", func() {
+		const sqlHandler = `
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+func lookup(db *sql.DB, name string) {
+	db.Query("SELECT * FROM users WHERE name = '" + name + "'") %s
+}
+
+func handler(db *sql.DB, r *http.Request) {
+	name := r.FormValue("name") %s
+	lookup(db, name)
+}
+
+func main() {}
+`
+		runG701 := func(sinkComment, sourceComment string) int {
+			analyzer.LoadAnalyzers(analyzers.Generate(false, analyzers.NewAnalyzerFilter(false, "G701")).AnalyzersInfo())
+			pkg := testutils.NewTestPackage()
+			defer pkg.Close()
+			pkg.AddFile("main.go", fmt.Sprintf(sqlHandler, sinkComment, sourceComment))
+			err := pkg.Build()
+			Expect(err).ShouldNot(HaveOccurred())
+			err = analyzer.Process(buildTags, pkg.Path)
+			Expect(err).ShouldNot(HaveOccurred())
+			issues, _, _ := analyzer.Report()
+			return len(issues)
+		}
+
+		It("should report the issue without annotations", func() {
+			Expect(runG701("", "")).To(Equal(1))
+		})
+
+		It("should suppress the issue with #nosec at the sink", func() {
+			Expect(runG701("// #nosec", "")).To(Equal(0))
+		})
+
+		It("should suppress the issue with #nosec G701 at the sink", func() {
+			Expect(runG701("// #nosec G701 -- name is validated by the caller", "")).To(Equal(0))
+		})
+
+		It("should suppress the issue with #nosec G701 at the source", func() {
+			Expect(runG701("", "// #nosec G701 -- reviewed")).To(Equal(0))
+		})
+
+		It("should not suppress the issue with #nosec for another rule", func() {
+			Expect(runG701("// #nosec G702", "// #nosec G702")).To(Equal(1))
+		})
+
+		It("should not suppress the issue with #nosec on a shared helper in its flow", func() {
+			analyzer.LoadAnalyzers(analyzers.Generate(false, analyzers.NewAnalyzerFilter(false, "G701")).AnalyzersInfo())
+			pkg := testutils.NewTestPackage()
+			defer pkg.Close()
+			pkg.AddFile("main.go", `
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+func lookup(
+	db *sql.DB,
+	name string, // #nosec G701 -- reviewed for the admin handler
+) {
+	db.Query("SELECT * FROM users WHERE name = '" + name + "'")
+}
+
+func admin(db *sql.DB, r *http.Request) {
+	lookup(db, r.FormValue("name"))
+}
+
+func search(db *sql.DB, r *http.Request) {
+	lookup(db, r.FormValue("q"))
+}
+
+func main() {}
+`)
+			err := pkg.Build()
+			Expect(err).ShouldNot(HaveOccurred())
+			err = analyzer.Process(buildTags, pkg.Path)
+			Expect(err).ShouldNot(HaveOccurred())
+			issues, _, _ := analyzer.Report()
+			Expect(issues).To(HaveLen(1))
+		})
+	})
+
+	Context("when overriding rule severity and confidence", func() {
+		const lostCancel = `
+package main
+
+import (
+	"context"
+	"time"
+)
+
+func work(ctx context.Context) {
+	child, _ := context.WithTimeout(ctx, time.Second)
+	_ = child
+}
+
+func main() {}
+`
+		const mapFlow = `
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+func handler(db *sql.DB, r *http.Request) {
+	params := map[string]string{"name": r.FormValue("name")}
+	db.Query("SELECT * FROM users WHERE name = '" + params["name"] + "'")
+}
+
+func main() {}
+`
+		run := func(ruleID, source string, overrides map[string]gosec.RuleOverride) []*issue.Issue {
+			config := gosec.NewConfig()
+			config.SetRuleOverrides(overrides)
+			analyzer = gosec.NewAnalyzer(config, tests, false, false, 1, logger)
+			analyzer.LoadAnalyzers(analyzers.Generate(false, analyzers.NewAnalyzerFilter(false, ruleID)).AnalyzersInfo())
+			pkg := testutils.NewTestPackage()
+			defer pkg.Close()
+			pkg.AddFile("main.go", source)
+			err := pkg.Build()
+			Expect(err).ShouldNot(HaveOccurred())
+			err = analyzer.Process(buildTags, pkg.Path)
+			Expect(err).ShouldNot(HaveOccurred())
+			issues, _, _ := analyzer.Report()
+			return issues
+		}
+
+		It("should report the rule's own severity without an override", func() {
+			issues := run("G118", lostCancel, nil)
+			Expect(issues).To(HaveLen(1))
+			Expect(issues[0].Severity).To(Equal(issue.Medium))
+		})
+
+		It("should report the configured severity", func() {
+			issues := run("G118", lostCancel, map[string]gosec.RuleOverride{
+				"G118": {Severity: "high"},
+			})
+			Expect(issues).To(HaveLen(1))
+			Expect(issues[0].Severity).To(Equal(issue.High))
+		})
+
+		It("should not change the severity of other rules", func() {
+			issues := run("G118", lostCancel, map[string]gosec.RuleOverride{
+				"G701": {Severity: "low"},
+			})
+			Expect(issues).To(HaveLen(1))
+			Expect(issues[0].Severity).To(Equal(issue.Medium))
+		})
+
+		It("should drop issues below the configured confidence", func() {
+			Expect(run("G701", mapFlow, nil)).To(HaveLen(1))
+			Expect(run("G701", mapFlow, map[string]gosec.RuleOverride{
+				"G701": {MinConfidence: "high"},
+			})).To(BeEmpty())
+		})
+
+		It("should keep issues at the configured confidence", func() {
+			Expect(run("G118", lostCancel, map[string]gosec.RuleOverride{
+				"G118": {MinConfidence: "high"},
+			})).To(HaveLen(1))
+		})
+	})
+
 	Context("when fixing issue #1240 - nosec with open bracket", func() {
 		It("should suppress G115 when #nosec is at the end of an if line with bracket", func() {
 			source := `
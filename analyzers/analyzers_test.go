@@ -138,5 +138,21 @@ var _ = Describe("gosec analyzers", func() {
 		It("should detect open redirect via taint analysis", func() {
 			runner("G710", testutils.SampleCodeG710)
 		})
+
+		It("should detect regular expressions compiled from user input via taint analysis", func() {
+			runner("G712", testutils.SampleCodeG712)
+		})
+
+		It("should detect environment variables set from user input via taint analysis", func() {
+			runner("G714", testutils.SampleCodeG714)
+		})
+
+		It("should detect tainted format strings via taint analysis", func() {
+			runner("G715", testutils.SampleCodeG715)
+		})
+
+		It("should detect HTTP header injection via taint analysis", func() {
+			runner("G716", testutils.SampleCodeG716)
+		})
 	})
 })
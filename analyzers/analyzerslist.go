@@ -103,6 +103,48 @@ var (
 		CWE:         "CWE-601",
 	}
 
+	NoSQLInjectionRule = taint.RuleInfo{
+		ID:          "G711",
+		Description: "NoSQL injection: user-controlled input flows into MongoDB query logic",
+		Severity:    "HIGH",
+		CWE:         "CWE-943",
+	}
+
+	RegexpInjectionRule = taint.RuleInfo{
+		ID:          "G712",
+		Description: "Regular expression compiled from user-controlled input",
+		Severity:    "MEDIUM",
+		CWE:         "CWE-1333",
+	}
+
+	LDAPInjectionRule = taint.RuleInfo{
+		ID:          "G713",
+		Description: "LDAP injection: user-controlled input flows into an LDAP search filter",
+		Severity:    "HIGH",
+		CWE:         "CWE-90",
+	}
+
+	EnvInjectionRule = taint.RuleInfo{
+		ID:          "G714",
+		Description: "Environment variable set from user-controlled input",
+		Severity:    "MEDIUM",
+		CWE:         "CWE-15",
+	}
+
+	FormatStringRule = taint.RuleInfo{
+		ID:          "G715",
+		Description: "Format string built from user-controlled input",
+		Severity:    "MEDIUM",
+		CWE:         "CWE-134",
+	}
+
+	HeaderInjectionRule = taint.RuleInfo{
+		ID:          "G716",
+		Description: "HTTP header value built from user-controlled input",
+		Severity:    "MEDIUM",
+		CWE:         "CWE-113",
+	}
+
 	FormParsingLimitRule = taint.RuleInfo{
 		ID:          "G120",
 		Description: "Unbounded multipart form parsing can cause memory exhaustion",
@@ -170,6 +212,12 @@ var defaultAnalyzers = []AnalyzerDefinition{
 	{"G708", "Server-side template injection via taint analysis", newSSTIAnalyzer},
 	{"G709", "Unsafe deserialization of untrusted data via taint analysis", newUnsafeDeserializationAnalyzer},
 	{"G710", "Open redirect via taint analysis", newOpenRedirectAnalyzer},
+	{"G711", "NoSQL injection via taint analysis", newNoSQLInjectionAnalyzer},
+	{"G712", "Regular expression injection via taint analysis", newRegexpInjectionAnalyzer},
+	{"G713", "LDAP injection via taint analysis", newLDAPInjectionAnalyzer},
+	{"G714", "Environment variable injection via taint analysis", newEnvInjectionAnalyzer},
+	{"G715", "Format string injection via taint analysis", newFormatStringAnalyzer},
+	{"G716", "HTTP header injection via taint analysis", newHeaderInjectionAnalyzer},
 }
 
 // Generate the list of analyzers to use
@@ -195,31 +243,73 @@ func Generate(trackSuppressions bool, filters ...AnalyzerFilter) *AnalyzerList {
 	return &AnalyzerList{Analyzers: analyzerMap, AnalyzerSuppressed: analyzerSuppressedMap}
 }
 
+// taintRules lists the rules implemented with taint analysis.
+var taintRules = []*taint.RuleInfo{
+	&SQLInjectionRule,
+	&CommandInjectionRule,
+	&PathTraversalRule,
+	&SSRFRule,
+	&XSSRule,
+	&LogInjectionRule,
+	&SMTPInjectionRule,
+	&SSTIRule,
+	&UnsafeDeserializationRule,
+	&FormParsingLimitRule,
+	&OpenRedirectRule,
+	&NoSQLInjectionRule,
+	&RegexpInjectionRule,
+	&LDAPInjectionRule,
+	&EnvInjectionRule,
+	&FormatStringRule,
+	&HeaderInjectionRule,
+}
+
+// IsTaintRule checks if the analyzer with the given ID is implemented with
+// taint analysis.
+func IsTaintRule(id string) bool {
+	for _, rule := range taintRules {
+		if rule.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// taintRule is a predefined taint analysis rule with its configuration.
+type taintRule struct {
+	rule   *taint.RuleInfo
+	config taint.Config
+}
+
+// defaultTaintRules returns all predefined taint analysis rules.
+func defaultTaintRules() []taintRule {
+	return []taintRule{
+		{&SQLInjectionRule, SQLInjection()},
+		{&CommandInjectionRule, CommandInjection()},
+		{&PathTraversalRule, PathTraversal()},
+		{&SSRFRule, SSRF()},
+		{&XSSRule, XSS()},
+		{&LogInjectionRule, LogInjection()},
+		{&SMTPInjectionRule, SMTPInjection()},
+		{&SSTIRule, SSTI()},
+		{&UnsafeDeserializationRule, UnsafeDeserialization()},
+		{&FormParsingLimitRule, FormParsingLimits()},
+		{&OpenRedirectRule, OpenRedirect()},
+		{&NoSQLInjectionRule, NoSQLInjection()},
+		{&RegexpInjectionRule, RegexpInjection()},
+		{&LDAPInjectionRule, LDAPInjection()},
+		{&EnvInjectionRule, EnvInjection()},
+		{&FormatStringRule, FormatString()},
+		{&HeaderInjectionRule, HeaderInjection()},
+	}
+}
+
 // DefaultTaintAnalyzers returns all predefined taint analysis analyzers.
 func DefaultTaintAnalyzers() []*analysis.Analyzer {
-	sqlConfig := SQLInjection()
-	cmdConfig := CommandInjection()
-	pathConfig := PathTraversal()
-	ssrfConfig := SSRF()
-	xssConfig := XSS()
-	logConfig := LogInjection()
-	smtpConfig := SMTPInjection()
-	sstiConfig := SSTI()
-	deserConfig := UnsafeDeserialization()
-	formConfig := FormParsingLimits()
-	openRedirectConfig := OpenRedirect()
-
-	return []*analysis.Analyzer{
-		taint.NewGosecAnalyzer(&SQLInjectionRule, &sqlConfig),
-		taint.NewGosecAnalyzer(&CommandInjectionRule, &cmdConfig),
-		taint.NewGosecAnalyzer(&PathTraversalRule, &pathConfig),
-		taint.NewGosecAnalyzer(&SSRFRule, &ssrfConfig),
-		taint.NewGosecAnalyzer(&XSSRule, &xssConfig),
-		taint.NewGosecAnalyzer(&LogInjectionRule, &logConfig),
-		taint.NewGosecAnalyzer(&SMTPInjectionRule, &smtpConfig),
-		taint.NewGosecAnalyzer(&SSTIRule, &sstiConfig),
-		taint.NewGosecAnalyzer(&UnsafeDeserializationRule, &deserConfig),
-		taint.NewGosecAnalyzer(&FormParsingLimitRule, &formConfig),
-		taint.NewGosecAnalyzer(&OpenRedirectRule, &openRedirectConfig),
+	rules := defaultTaintRules()
+	taintAnalyzers := make([]*analysis.Analyzer, 0, len(rules))
+	for _, r := range rules {
+		taintAnalyzers = append(taintAnalyzers, taint.NewGosecAnalyzer(r.rule, &r.config))
 	}
+	return taintAnalyzers
 }
@@ -86,6 +86,42 @@ func TestTaintAnalyzerConstructors(t *testing.T) {
 			id:          "G710",
 			description: "Open redirect via taint analysis",
 		},
+		{
+			name:        "NoSQLInjection",
+			constructor: newNoSQLInjectionAnalyzer,
+			id:          "G711",
+			description: "NoSQL injection via taint analysis",
+		},
+		{
+			name:        "RegexpInjection",
+			constructor: newRegexpInjectionAnalyzer,
+			id:          "G712",
+			description: "Regular expression injection via taint analysis",
+		},
+		{
+			name:        "LDAPInjection",
+			constructor: newLDAPInjectionAnalyzer,
+			id:          "G713",
+			description: "LDAP injection via taint analysis",
+		},
+		{
+			name:        "EnvInjection",
+			constructor: newEnvInjectionAnalyzer,
+			id:          "G714",
+			description: "Environment variable injection via taint analysis",
+		},
+		{
+			name:        "FormatString",
+			constructor: newFormatStringAnalyzer,
+			id:          "G715",
+			description: "Format string injection via taint analysis",
+		},
+		{
+			name:        "HeaderInjection",
+			constructor: newHeaderInjectionAnalyzer,
+			id:          "G716",
+			description: "HTTP header injection via taint analysis",
+		},
 		{
 			name:        "FormParsingLimit",
 			constructor: newFormParsingLimitAnalyzer,
@@ -125,7 +161,7 @@ func TestTaintAnalyzerConstructors(t *testing.T) {
 
 // TestDefaultAnalyzersIncludeTaint tests that default analyzers include taint rules.
 func TestDefaultAnalyzersIncludeTaint(t *testing.T) {
-	expectedTaintIDs := []string{"G701", "G702", "G703", "G704", "G705", "G706", "G707", "G708", "G709", "G710"}
+	expectedTaintIDs := []string{"G701", "G702", "G703", "G704", "G705", "G706", "G707", "G708", "G709", "G710", "G711", "G712", "G713", "G714", "G715", "G716"}
 
 	found := make(map[string]bool)
 	for _, def := range defaultAnalyzers {
@@ -143,7 +179,7 @@ func TestDefaultAnalyzersIncludeTaint(t *testing.T) {
 func TestGenerateIncludesTaintAnalyzers(t *testing.T) {
 	analyzerList := Generate(false)
 
-	expectedTaintIDs := []string{"G701", "G702", "G703", "G704", "G705", "G706", "G707", "G708", "G709", "G710"}
+	expectedTaintIDs := []string{"G701", "G702", "G703", "G704", "G705", "G706", "G707", "G708", "G709", "G710", "G711", "G712", "G713", "G714", "G715", "G716"}
 
 	for _, id := range expectedTaintIDs {
 		if _, ok := analyzerList.Analyzers[id]; !ok {
@@ -152,6 +188,20 @@ func TestGenerateIncludesTaintAnalyzers(t *testing.T) {
 	}
 }
 
+// TestIsTaintRule tests that only the taint analyzers are taint rules.
+func TestIsTaintRule(t *testing.T) {
+	for _, id := range []string{"G120", "G701", "G706", "G714", "G716"} {
+		if !IsTaintRule(id) {
+			t.Errorf("%s should be a taint rule", id)
+		}
+	}
+	for _, id := range []string{"G115", "G118", "G602", "G717"} {
+		if IsTaintRule(id) {
+			t.Errorf("%s should not be a taint rule", id)
+		}
+	}
+}
+
 // TestGenerateExcludeTaintAnalyzers tests that taint analyzers can be excluded.
 func TestGenerateExcludeTaintAnalyzers(t *testing.T) {
 	filter := NewAnalyzerFilter(true, "G701", "G702")
@@ -308,7 +358,7 @@ func TestAnalyzerList_AnalyzersInfo(t *testing.T) {
 func TestDefaultTaintAnalyzers(t *testing.T) {
 	analyzers := DefaultTaintAnalyzers()
 
-	expectedCount := 11 // SQL, Command, Path, SSRF, XSS, Log, SMTP, SSTI, Deserialization, FormParsing, OpenRedirect
+	expectedCount := 17 // SQL, Command, Path, SSRF, XSS, Log, SMTP, SSTI, Deserialization, FormParsing, OpenRedirect, NoSQL, Regexp, LDAP, EnvInjection, FormatString, HeaderInjection
 	if len(analyzers) != expectedCount {
 		t.Errorf("Expected %d taint analyzers, got %d", expectedCount, len(analyzers))
 	}
@@ -324,6 +374,12 @@ func TestDefaultTaintAnalyzers(t *testing.T) {
 		"G708": false,
 		"G709": false,
 		"G710": false,
+		"G711": false,
+		"G712": false,
+		"G713": false,
+		"G714": false,
+		"G715": false,
+		"G716": false,
 		"G120": false,
 	}
 
@@ -15,6 +15,8 @@
 package analyzers
 
 import (
+	"slices"
+
 	"golang.org/x/tools/go/analysis"
 
 	"github.com/securego/gosec/v2/taint"
@@ -23,29 +25,22 @@ import (
 // CommandInjection returns a configuration for detecting command injection vulnerabilities.
 func CommandInjection() taint.Config {
 	return taint.Config{
-		Sources: []taint.Source{
-			// Type sources: tainted when received as parameters
-			{Package: "net/http", Name: "Request", Pointer: true},
-			{Package: "bufio", Name: "Reader", Pointer: true},
-			{Package: "bufio", Name: "Scanner", Pointer: true},
-
-			// Function sources
-			{Package: "os", Name: "Args", IsFunc: true},
-			{Package: "os", Name: "Getenv", IsFunc: true},
-		},
+		// Command injection shares G701's sources of untrusted input
+		Sources: slices.Clone(sqlInjectionSources),
 		Sinks: []taint.Sink{
 			// Detect at command creation, not execution (avoids double detection)
 			{Package: "os/exec", Method: "Command"},
-			{Package: "os/exec", Method: "CommandContext"},
+			// Args[0] is the context; Args[1] is the program and Args[2] the variadic args
+			{Package: "os/exec", Method: "CommandContext", CheckArgs: []int{1, 2}},
 			{Package: "os", Method: "StartProcess"},
 			{Package: "syscall", Method: "Exec"},
 			{Package: "syscall", Method: "ForkExec"},
 			{Package: "syscall", Method: "StartProcess"},
 		},
-		Sanitizers: []taint.Sanitizer{
-			// No general-purpose stdlib sanitizer for command injection.
-			// The proper fix is to use exec.Command with separate args, not shell strings.
-		},
+		// No general-purpose stdlib sanitizer for command injection beyond numeric
+		// conversions. The proper fix is to use exec.Command with separate args,
+		// not shell strings.
+		Sanitizers: slices.Clone(numericSanitizers),
 	}
 }
 
@@ -15,8 +15,12 @@
 package analyzers
 
 import (
+	"fmt"
+	"go/ast"
 	"go/token"
 	"go/types"
+	"slices"
+	"strings"
 
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/passes/buildssa"
@@ -24,15 +28,41 @@ import (
 
 	"github.com/securego/gosec/v2/internal/ssautil"
 	"github.com/securego/gosec/v2/issue"
+	"github.com/securego/gosec/v2/taint"
 )
 
 const (
-	contextPkgPath = "context"
-	httpPkgPath    = "net/http"
+	contextPkgPath  = "context"
+	httpPkgPath     = "net/http"
+	errgroupPkgPath = "golang.org/x/sync/errgroup"
 
 	msgContextBackground = "Goroutine uses context.Background/TODO while request-scoped context is available"
 	msgLostCancel        = "context cancellation function returned by WithCancel/WithTimeout/WithDeadline is not called"
+	msgCancelNotOnReturn = "context cancellation function returned by WithCancel/WithTimeout/WithDeadline is not called on every return path"
 	msgLoopWithoutDone   = "Long-running loop performs calls without a ctx.Done() cancellation guard"
+	msgTimerNotStopped   = "time.Ticker/Timer created by NewTicker/NewTimer is never stopped"
+	msgBlockedSend       = "Goroutine sends on an unbuffered channel that is never received from and blocks forever"
+	msgUnboundedSpawn    = "Loop launches goroutines without a concurrency limit or ctx.Done() exit"
+	msgRequestBackground = "context.Background/TODO used while request-scoped context is available"
+
+	// contextPropagationBlocking is the rule setting listing extra function
+	// signatures to treat as blocking calls
+	contextPropagationBlocking = "blocking"
+	// contextPropagationCancelOwners is the rule setting listing functions that
+	// take ownership of a cancel function passed to them, even inside a struct
+	contextPropagationCancelOwners = "cancel_owners"
+	// contextPropagationBlockingDepth is the rule setting for the number of
+	// calls followed into helper functions to find a blocking call
+	contextPropagationBlockingDepth = "blocking_depth"
+
+	// contextPropagationRequestBackground is the opt-in rule setting reporting
+	// any context.Background/TODO call in request-scoped code, not only in
+	// goroutines
+	contextPropagationRequestBackground = "request_background"
+
+	// defaultBlockingDepth makes a loop calling a helper that blocks count as
+	// blocking, but not one calling a helper that calls such a helper
+	defaultBlockingDepth = 1
 )
 
 func newContextPropagationAnalyzer(id string, description string) *analysis.Analyzer {
@@ -48,6 +78,19 @@ type contextPropagationState struct {
 	*BaseAnalyzerState
 	ssaFuncs []*ssa.Function
 	issues   map[token.Pos]*issue.Issue
+	blocking map[string]struct{} // configured blocking functions, keyed by ssa.Function.String()
+	owners   map[string]struct{} // configured cancel owners, keyed by ssa.Function.String()
+	depth    int                 // calls followed into helpers to find a blocking call
+	blockers map[blockingKey]bool
+	// requestBackground reports context.Background/TODO calls made directly
+	// in request-scoped functions
+	requestBackground bool
+}
+
+// blockingKey memoizes whether a function blocks when followed to a depth.
+type blockingKey struct {
+	fn    *ssa.Function
+	depth int
 }
 
 func newContextPropagationState(pass *analysis.Pass, funcs []*ssa.Function) *contextPropagationState {
@@ -55,6 +98,8 @@ func newContextPropagationState(pass *analysis.Pass, funcs []*ssa.Function) *con
 		BaseAnalyzerState: NewBaseState(pass),
 		ssaFuncs:          funcs,
 		issues:            make(map[token.Pos]*issue.Issue),
+		depth:             defaultBlockingDepth,
+		blockers:          make(map[blockingKey]bool),
 	}
 }
 
@@ -77,6 +122,25 @@ func runContextPropagationAnalysis(pass *analysis.Pass) (any, error) {
 	state := newContextPropagationState(pass, ssaResult.SSA.SrcFuncs)
 	defer state.Release()
 
+	settings, _ := ssaResult.Config[pass.Analyzer.Name].(map[string]any)
+	if state.blocking, err = configuredFuncs(settings, contextPropagationBlocking); err != nil {
+		return nil, fmt.Errorf("%s: %w", pass.Analyzer.Name, err)
+	}
+	if state.owners, err = configuredFuncs(settings, contextPropagationCancelOwners); err != nil {
+		return nil, fmt.Errorf("%s: %w", pass.Analyzer.Name, err)
+	}
+	if raw, ok := settings[contextPropagationBlockingDepth]; ok {
+		if state.depth, err = configuredDepth(raw); err != nil {
+			return nil, fmt.Errorf("%s: %s: %w", pass.Analyzer.Name, contextPropagationBlockingDepth, err)
+		}
+	}
+
+	if raw, ok := settings[contextPropagationRequestBackground]; ok {
+		if state.requestBackground, ok = raw.(bool); !ok {
+			return nil, fmt.Errorf("%s: %s: expected a boolean, got %T", pass.Analyzer.Name, contextPropagationRequestBackground, raw)
+		}
+	}
+
 	for _, fn := range state.ssaFuncs {
 		if fn == nil || len(fn.Blocks) == 0 {
 			continue
@@ -85,12 +149,20 @@ func runContextPropagationAnalysis(pass *analysis.Pass) (any, error) {
 		hasRequestContext := functionHasRequestContext(fn)
 		ctxValues := collectContextValues(fn)
 
+		// Reported at the loop position, so an unbounded spawn takes
+		// precedence over the missing cancellation guard of the same loop
+		state.detectUnboundedSpawns(fn)
 		if hasRequestContext {
 			state.detectUnsafeGoroutines(fn, ctxValues)
+			if state.requestBackground {
+				state.detectRequestBackground(fn)
+			}
 			state.detectLoopsWithoutCancellationGuard(fn, ctxValues)
 		}
 
 		state.detectLostCancel(fn)
+		state.detectUnstoppedTimers(fn)
+		state.detectBlockedSends(fn)
 	}
 
 	if len(state.issues) == 0 {
@@ -105,6 +177,41 @@ func runContextPropagationAnalysis(pass *analysis.Pass) (any, error) {
 	return issues, nil
 }
 
+// configuredFuncs reads the list of function signatures under key in the
+// rule settings. It returns nil when the key is not set.
+func configuredFuncs(settings map[string]any, key string) (map[string]struct{}, error) {
+	raw, ok := settings[key]
+	if !ok {
+		return nil, nil
+	}
+	funcs, err := taint.FunctionKeys(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", key, err)
+	}
+	return funcs, nil
+}
+
+// configuredDepth reads a call depth from the rule settings, where zero
+// disables following calls.
+func configuredDepth(value any) (int, error) {
+	var depth int
+	switch v := value.(type) {
+	case int:
+		depth = v
+	case float64:
+		if v != float64(int(v)) {
+			return 0, fmt.Errorf("expected an integer, got %v", v)
+		}
+		depth = int(v)
+	default:
+		return 0, fmt.Errorf("expected an integer, got %T", value)
+	}
+	if depth < 0 {
+		return 0, fmt.Errorf("expected a non-negative integer, got %d", depth)
+	}
+	return depth, nil
+}
+
 func functionHasRequestContext(fn *ssa.Function) bool {
 	if fn.Signature == nil {
 		return false
@@ -122,11 +229,38 @@ func functionHasRequestContext(fn *ssa.Function) bool {
 		if isHTTPRequestPointerType(p.Type()) {
 			return true
 		}
+		if wrapsRequest(p.Type()) {
+			return true
+		}
+	}
+
+	// Handlers written as methods of the wrapper, e.g. func (c *Ctx) Serve()
+	if recv := fn.Signature.Recv(); recv != nil && wrapsRequest(recv.Type()) {
+		return true
 	}
 
 	return false
 }
 
+// wrapsRequest reports whether t is a struct, or a pointer to one, holding
+// the request in a field, as middleware does to pass the request on to
+// handlers under its own type.
+func wrapsRequest(t types.Type) bool {
+	if ptr, ok := t.Underlying().(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	st, ok := t.Underlying().(*types.Struct)
+	if !ok {
+		return false
+	}
+	for i := 0; i < st.NumFields(); i++ {
+		if isHTTPRequestPointerType(st.Field(i).Type()) {
+			return true
+		}
+	}
+	return false
+}
+
 func collectContextValues(fn *ssa.Function) map[ssa.Value]struct{} {
 	ctxVals := make(map[ssa.Value]struct{})
 
@@ -157,6 +291,16 @@ func collectContextValues(fn *ssa.Function) map[ssa.Value]struct{} {
 				continue
 			}
 
+			if isErrgroupWithContext(common) {
+				// g, ctx := errgroup.WithContext(parent)
+				for _, ref := range safeReferrers(callInstr.Value()) {
+					if extract, ok := ref.(*ssa.Extract); ok && extract.Index == 1 {
+						ctxVals[extract] = struct{}{}
+					}
+				}
+				continue
+			}
+
 			if !isContextWithFamily(common) {
 				continue
 			}
@@ -180,25 +324,25 @@ func collectContextValues(fn *ssa.Function) map[ssa.Value]struct{} {
 func (s *contextPropagationState) detectUnsafeGoroutines(fn *ssa.Function, contextValues map[ssa.Value]struct{}) {
 	for _, block := range fn.Blocks {
 		for _, instr := range block.Instrs {
-			goInstr, ok := instr.(*ssa.Go)
-			if !ok {
+			launched := launchedFunc(instr)
+			if launched == nil {
 				continue
 			}
 
 			hasBackgroundCtx := false
-			for _, arg := range goInstr.Call.Args {
-				if isBackgroundOrTodoValue(arg) {
-					hasBackgroundCtx = true
-					break
+			if goInstr, ok := instr.(*ssa.Go); ok {
+				for _, arg := range goInstr.Call.Args {
+					if isBackgroundOrTodoValue(arg) {
+						hasBackgroundCtx = true
+						break
+					}
 				}
 			}
 
 			if !hasBackgroundCtx {
-				for _, callee := range resolveGoCallTargets(goInstr) {
-					if callee == nil {
-						continue
-					}
-					if functionCallsBackground(callee) {
+				visited := make(map[*ssa.Function]bool)
+				for _, callee := range resolveFuncs(launched) {
+					if goroutineCallsBackground(callee, visited) {
 						hasBackgroundCtx = true
 						break
 					}
@@ -206,7 +350,31 @@ func (s *contextPropagationState) detectUnsafeGoroutines(fn *ssa.Function, conte
 			}
 
 			if hasBackgroundCtx && len(contextValues) > 0 {
-				s.addIssue(goInstr.Pos(), msgContextBackground, issue.High, issue.Medium)
+				s.addIssue(instr.Pos(), msgContextBackground, issue.High, issue.Medium)
+			}
+		}
+	}
+}
+
+// detectRequestBackground reports calls to context.Background or
+// context.TODO made in a function that has a request or a context available,
+// which should be propagated instead. Calls made in goroutines are reported
+// by detectUnsafeGoroutines.
+func (s *contextPropagationState) detectRequestBackground(fn *ssa.Function) {
+	for _, block := range fn.Blocks {
+		for _, instr := range block.Instrs {
+			call, ok := instr.(*ssa.Call)
+			if !ok || !isBackgroundOrTodoCall(call.Common()) {
+				continue
+			}
+			launchesGoroutine := false
+			for _, ref := range safeReferrers(call) {
+				if _, ok := ref.(*ssa.Go); ok {
+					launchesGoroutine = true
+				}
+			}
+			if !launchesGoroutine {
+				s.addIssue(call.Pos(), msgRequestBackground, issue.Medium, issue.High)
 			}
 		}
 	}
@@ -234,11 +402,305 @@ func (s *contextPropagationState) detectLostCancel(fn *ssa.Function) {
 				continue
 			}
 
-			if !isCancelCalled(cancelValue, s.ssaFuncs) {
+			if !isCancelCalled(cancelValue, s.ssaFuncs, s.owners) {
 				s.addIssue(instr.Pos(), msgLostCancel, issue.Medium, issue.High)
+				if iss := s.issues[instr.Pos()]; iss != nil && iss.Autofix == "" {
+					iss.Autofix = s.deferCancelSuggestion(fn, instr.Pos())
+				}
+			} else if returnsWithoutCancel(instr, cancelUses(cancelValue)) {
+				s.addIssue(instr.Pos(), msgCancelNotOnReturn, issue.Medium, issue.High)
+				if iss := s.issues[instr.Pos()]; iss != nil && iss.Autofix == "" {
+					iss.Autofix = "Defer the cancel function right after the context is created, before any return"
+				}
+			}
+		}
+	}
+}
+
+// cancelUses returns the instructions of the function creating a cancel
+// function that call it, defer it or hand it over, such as by capturing it in
+// a closure or returning it. Values that only alias the cancel function, such
+// as the local variable holding it when a closure captures it, are followed to
+// their own uses.
+func cancelUses(cancelValue ssa.Value) map[ssa.Instruction]bool {
+	uses := make(map[ssa.Instruction]bool)
+	queue := []ssa.Value{cancelValue}
+	visited := make(map[ssa.Value]bool)
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if visited[current] {
+			continue
+		}
+		visited[current] = true
+		for _, ref := range safeReferrers(current) {
+			switch r := ref.(type) {
+			case *ssa.DebugRef:
+			case *ssa.Phi, *ssa.ChangeType, *ssa.Convert, *ssa.MakeInterface:
+				queue = append(queue, r.(ssa.Value))
+			case *ssa.Store:
+				if r.Addr == current {
+					continue
+				}
+				if alloc, ok := r.Addr.(*ssa.Alloc); ok {
+					queue = append(queue, alloc)
+					continue
+				}
+				uses[r] = true
+			case *ssa.UnOp:
+				if r.Op == token.MUL {
+					queue = append(queue, r)
+					continue
+				}
+				uses[r] = true
+			default:
+				uses[r] = true
+			}
+		}
+	}
+	return uses
+}
+
+// returnsWithoutCancel reports whether the function can return after the
+// context creating call without going through one of the uses of its cancel
+// function, as with an early return placed before "defer cancel()".
+func returnsWithoutCancel(call ssa.Instruction, uses map[ssa.Instruction]bool) bool {
+	start := call.Block()
+	after := false
+	for _, instr := range start.Instrs {
+		if instr == call {
+			after = true
+			continue
+		}
+		if after && uses[instr] {
+			return false
+		}
+	}
+	if _, ok := start.Instrs[len(start.Instrs)-1].(*ssa.Return); ok {
+		return true
+	}
+
+	visited := map[*ssa.BasicBlock]bool{start: true}
+	queue := slices.Clone(start.Succs)
+	for len(queue) > 0 {
+		block := queue[0]
+		queue = queue[1:]
+		if visited[block] {
+			continue
+		}
+		visited[block] = true
+		if slices.ContainsFunc(block.Instrs, func(instr ssa.Instruction) bool { return uses[instr] }) {
+			continue
+		}
+		if _, ok := block.Instrs[len(block.Instrs)-1].(*ssa.Return); ok {
+			return true
+		}
+		queue = append(queue, block.Succs...)
+	}
+	return false
+}
+
+// deferCancelSuggestion returns an Autofix suggestion to defer the cancel
+// function of the WithCancel family call at pos. There is none unless the
+// call is assigned in a statement directly in the function body, since a
+// defer in a loop only runs when the function returns, and unless the cancel
+// function is dropped or only assigned to the blank identifier, since
+// another use may hand it over to code that calls it.
+func (s *contextPropagationState) deferCancelSuggestion(fn *ssa.Function, pos token.Pos) string {
+	var body *ast.BlockStmt
+	switch syntax := fn.Syntax().(type) {
+	case *ast.FuncDecl:
+		body = syntax.Body
+	case *ast.FuncLit:
+		body = syntax.Body
+	}
+	if body == nil {
+		return ""
+	}
+
+	for _, stmt := range body.List {
+		assign, ok := stmt.(*ast.AssignStmt)
+		if !ok || len(assign.Lhs) != 2 || len(assign.Rhs) != 1 {
+			continue
+		}
+		call, ok := assign.Rhs[0].(*ast.CallExpr)
+		if !ok || call.Lparen != pos {
+			continue
+		}
+		cancel, ok := assign.Lhs[1].(*ast.Ident)
+		if !ok {
+			return ""
+		}
+
+		if cancel.Name == "_" {
+			// Only a new variable can take the place of the blank identifier,
+			// and it must not shadow or clash with another cancel
+			scope := s.Pass.Pkg.Scope().Innermost(assign.Pos())
+			if assign.Tok != token.DEFINE || scope == nil {
+				return ""
+			}
+			if _, obj := scope.LookupParent("cancel", assign.Pos()); obj != nil {
+				return ""
+			}
+		} else if obj := s.Pass.TypesInfo.Defs[cancel]; obj == nil || usedOtherThanBlank(body, obj, s.Pass.TypesInfo) {
+			return ""
+		}
+
+		lhs := []string{types.ExprString(assign.Lhs[0]), "cancel"}
+		return fmt.Sprintf("Defer the cancel function right after the context is created:\n\t%s %s %s\n\tdefer cancel()",
+			strings.Join(lhs, ", "), assign.Tok, types.ExprString(call))
+	}
+	return ""
+}
+
+// usedOtherThanBlank reports whether obj is used in body other than in an
+// assignment to the blank identifier, such as "_ = cancel".
+func usedOtherThanBlank(body *ast.BlockStmt, obj types.Object, info *types.Info) bool {
+	used := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		if used {
+			return false
+		}
+		if assign, ok := n.(*ast.AssignStmt); ok && len(assign.Lhs) == 1 && len(assign.Rhs) == 1 {
+			if lhs, ok := assign.Lhs[0].(*ast.Ident); ok && lhs.Name == "_" {
+				if rhs, ok := assign.Rhs[0].(*ast.Ident); ok && info.Uses[rhs] == obj {
+					return false
+				}
+			}
+		}
+		if ident, ok := n.(*ast.Ident); ok && info.Uses[ident] == obj {
+			used = true
+		}
+		return !used
+	})
+	return used
+}
+
+// detectUnstoppedTimers reports tickers and timers whose Stop method is never
+// called. Their results are tracked like cancel functions, so a deferred Stop,
+// a Stop through a struct field, or handing the value to a caller or another
+// function all count.
+func (s *contextPropagationState) detectUnstoppedTimers(fn *ssa.Function) {
+	for _, block := range fn.Blocks {
+		for _, instr := range block.Instrs {
+			call, ok := instr.(*ssa.Call)
+			if !ok || !isNewTimerCall(call.Common()) {
+				continue
+			}
+			if !isCancelCalled(call, s.ssaFuncs, s.owners) {
+				s.addIssue(call.Pos(), msgTimerNotStopped, issue.Medium, issue.High)
+			}
+		}
+	}
+}
+
+// detectBlockedSends reports goroutines sending on an unbuffered channel made
+// in fn that nothing receives from. To stay conservative, a channel is only
+// considered when all its uses are known: sends, close, and handing it to a
+// closure or a function with a body that uses it the same way.
+func (s *contextPropagationState) detectBlockedSends(fn *ssa.Function) {
+	for _, block := range fn.Blocks {
+		for _, instr := range block.Instrs {
+			makeChan, ok := instr.(*ssa.MakeChan)
+			if !ok {
+				continue
 			}
+			if size, ok := makeChan.Size.(*ssa.Const); !ok || size.Int64() != 0 {
+				continue
+			}
+			goSends := make(map[*ssa.Go]struct{})
+			if !onlySentTo(makeChan, nil, goSends, make(map[ssa.Value]bool)) {
+				continue
+			}
+			for launch := range goSends {
+				s.addIssue(launch.Pos(), msgBlockedSend, issue.Medium, issue.High)
+			}
+		}
+	}
+}
+
+// onlySentTo reports whether ch is only sent to or closed, following it into
+// the variables holding it and the closures and functions it is handed to.
+// Each go statement launching a function that sends on it is added to
+// goSends; launch is the go statement that started the function using ch, if
+// any.
+func onlySentTo(ch ssa.Value, launch *ssa.Go, goSends map[*ssa.Go]struct{}, visited map[ssa.Value]bool) bool {
+	if visited[ch] {
+		return true
+	}
+	visited[ch] = true
+
+	for _, ref := range safeReferrers(ch) {
+		switch r := ref.(type) {
+		case *ssa.DebugRef:
+		case *ssa.Send:
+			if r.Chan != ch {
+				return false
+			}
+			if launch != nil {
+				goSends[launch] = struct{}{}
+			}
+		case *ssa.ChangeType:
+			if !onlySentTo(r, launch, goSends, visited) {
+				return false
+			}
+		case *ssa.Store:
+			// A variable captured by a closure holds the channel; it may only
+			// be assigned the channel itself
+			if r.Addr == ch {
+				if !visited[r.Val] {
+					return false
+				}
+				continue
+			}
+			if _, isAlloc := r.Addr.(*ssa.Alloc); !isAlloc || !onlySentTo(r.Addr, launch, goSends, visited) {
+				return false
+			}
+		case *ssa.UnOp:
+			// Loading the channel from its variable, but not receiving from it
+			if r.Op != token.MUL || !onlySentTo(r, launch, goSends, visited) {
+				return false
+			}
+		case *ssa.MakeClosure:
+			fn, ok := r.Fn.(*ssa.Function)
+			if !ok {
+				return false
+			}
+			next := launch
+			for _, closureRef := range safeReferrers(r) {
+				if goInstr, isGo := closureRef.(*ssa.Go); isGo && goInstr.Call.Value == r {
+					next = goInstr
+				}
+			}
+			for i, binding := range r.Bindings {
+				if binding == ch && !onlySentTo(fn.FreeVars[i], next, goSends, visited) {
+					return false
+				}
+			}
+		case ssa.CallInstruction:
+			common := r.Common()
+			if builtin, ok := common.Value.(*ssa.Builtin); ok && builtin.Name() == "close" {
+				continue
+			}
+			callee := common.StaticCallee()
+			if callee == nil || len(callee.Blocks) == 0 || common.IsInvoke() {
+				return false
+			}
+			next := launch
+			if goInstr, isGo := r.(*ssa.Go); isGo {
+				next = goInstr
+			}
+			for i, arg := range common.Args {
+				if arg == ch && (i >= len(callee.Params) || !onlySentTo(callee.Params[i], next, goSends, visited)) {
+					return false
+				}
+			}
+		default:
+			// Receives, selects, and any other use that may lead to one
+			return false
 		}
 	}
+	return true
 }
 
 func (s *contextPropagationState) detectLoopsWithoutCancellationGuard(fn *ssa.Function, contextValues map[ssa.Value]struct{}) {
@@ -254,7 +716,7 @@ func (s *contextPropagationState) detectLoopsWithoutCancellationGuard(fn *ssa.Fu
 		if block == nil {
 			continue
 		}
-		features[block] = analyzeBlockFeatures(block)
+		features[block] = s.analyzeBlockFeatures(block)
 	}
 
 	regions := findLoopRegions(fn)
@@ -264,7 +726,8 @@ func (s *contextPropagationState) detectLoopsWithoutCancellationGuard(fn *ssa.Fu
 		}
 
 		hasDoneGuard := false
-		hasBlocking := false
+		hasBlocking := region.rangesOverChannel
+		var withoutContext *ssa.Function
 		for _, block := range region.blocks {
 			feature := features[block]
 			if feature.hasDoneGuard {
@@ -273,6 +736,9 @@ func (s *contextPropagationState) detectLoopsWithoutCancellationGuard(fn *ssa.Fu
 			if feature.hasBlocking {
 				hasBlocking = true
 			}
+			if withoutContext == nil {
+				withoutContext = feature.withoutContext
+			}
 			if hasDoneGuard && hasBlocking {
 				break
 			}
@@ -283,20 +749,125 @@ func (s *contextPropagationState) detectLoopsWithoutCancellationGuard(fn *ssa.Fu
 		}
 
 		s.addIssue(region.pos, msgLoopWithoutDone, issue.High, issue.Low)
+		if iss := s.issues[region.pos]; iss != nil && withoutContext != nil {
+			iss.Autofix = fmt.Sprintf("Call %s instead of %s so that it can be canceled, and return from the loop once ctx.Done() is closed",
+				contextAlternatives[withoutContext.String()], withoutContext)
+		}
 	}
 }
 
+// detectUnboundedSpawns reports loops that never exit and launch a goroutine
+// on every iteration, with nothing limiting how many run at once: no
+// semaphore or worker pool token, no wait for incoming work and no
+// ctx.Done() guard. Loops with an exit, as in for i := 0; i < n; i++, are
+// bounded by their condition.
+func (s *contextPropagationState) detectUnboundedSpawns(fn *ssa.Function) {
+	for _, region := range findLoopRegions(fn) {
+		if region.hasExternalExit || region.rangesOverChannel {
+			continue
+		}
+
+		spawns := false
+		limited := false
+		for _, block := range region.blocks {
+			for _, instr := range block.Instrs {
+				if _, ok := instr.(*ssa.Go); ok {
+					spawns = true
+				}
+				if limitsConcurrency(instr) {
+					limited = true
+				}
+			}
+			if s.analyzeBlockFeatures(block).hasDoneGuard {
+				limited = true
+			}
+		}
+
+		if spawns && !limited {
+			s.addIssue(region.pos, msgUnboundedSpawn, issue.Medium, issue.Medium)
+		}
+	}
+}
+
+// limitsConcurrency reports whether instr makes a loop wait before launching
+// more goroutines: a channel send or receive, as in a semaphore or worker
+// pool, a blocking select, or a call waiting for goroutines, a semaphore
+// or an incoming connection.
+func limitsConcurrency(instr ssa.Instruction) bool {
+	switch i := instr.(type) {
+	case *ssa.Send:
+		return true
+	case *ssa.UnOp:
+		return i.Op == token.ARROW
+	case *ssa.Select:
+		return i.Blocking
+	case *ssa.Call:
+		common := i.Common()
+		if common.IsInvoke() {
+			return common.Method.Name() == "Accept"
+		}
+		callee := common.StaticCallee()
+		if callee == nil {
+			return false
+		}
+		switch callee.String() {
+		case "(*sync.WaitGroup).Wait",
+			"(*sync.Cond).Wait",
+			"(*golang.org/x/sync/semaphore.Weighted).Acquire",
+			"(*net.TCPListener).Accept",
+			"(*net.TCPListener).AcceptTCP",
+			"(*net.UnixListener).Accept",
+			"(*net.UnixListener).AcceptUnix":
+			return true
+		}
+	}
+	return false
+}
+
 type blockFeatures struct {
 	hasDoneGuard bool
 	hasBlocking  bool
+	// withoutContext is a blocking function called without a context that
+	// has a context-aware alternative
+	withoutContext *ssa.Function
 }
 
-func analyzeBlockFeatures(block *ssa.BasicBlock) blockFeatures {
+// contextAlternatives maps blocking functions that take no context, keyed by
+// ssa.Function.String(), to their context-aware alternatives.
+var contextAlternatives = map[string]string{
+	"net.Dial":                    "(*net.Dialer).DialContext",
+	"net.DialTimeout":             "(*net.Dialer).DialContext",
+	"(*net.Dialer).Dial":          "(*net.Dialer).DialContext",
+	"net/http.Get":                "http.NewRequestWithContext with (*http.Client).Do",
+	"net/http.Head":               "http.NewRequestWithContext with (*http.Client).Do",
+	"net/http.Post":               "http.NewRequestWithContext with (*http.Client).Do",
+	"net/http.PostForm":           "http.NewRequestWithContext with (*http.Client).Do",
+	"(*net/http.Client).Get":      "http.NewRequestWithContext with (*http.Client).Do",
+	"(*database/sql.DB).Query":    "(*sql.DB).QueryContext",
+	"(*database/sql.DB).QueryRow": "(*sql.DB).QueryRowContext",
+	"(*database/sql.DB).Exec":     "(*sql.DB).ExecContext",
+	"(*database/sql.DB).Begin":    "(*sql.DB).BeginTx",
+	"(*database/sql.DB).Ping":     "(*sql.DB).PingContext",
+	"(*database/sql.Tx).Query":    "(*sql.Tx).QueryContext",
+	"(*database/sql.Tx).QueryRow": "(*sql.Tx).QueryRowContext",
+	"(*database/sql.Tx).Exec":     "(*sql.Tx).ExecContext",
+}
+
+func (s *contextPropagationState) analyzeBlockFeatures(block *ssa.BasicBlock) blockFeatures {
 	features := blockFeatures{}
 	for _, instr := range block.Instrs {
 		callInstr, ok := instr.(ssa.CallInstruction)
 		if !ok {
 			switch i := instr.(type) {
+			case *ssa.Select:
+				// A select without a default case blocks until one of its
+				// cases is ready; it is guarded if one of them is ctx.Done().
+				if selectReceivesFromDone(i) {
+					features.hasDoneGuard = true
+				}
+				if i.Blocking {
+					features.hasBlocking = true
+				}
 			case *ssa.Go:
 				features.hasBlocking = true
 			case *ssa.Call:
@@ -317,17 +888,78 @@ func analyzeBlockFeatures(block *ssa.BasicBlock) blockFeatures {
 		if isContextDoneCall(common) {
 			features.hasDoneGuard = true
 		}
-		if looksLikeBlockingCall(common) {
+		if s.isBlockingCall(common, s.depth) {
 			features.hasBlocking = true
 		}
+		if callee := common.StaticCallee(); callee != nil && features.withoutContext == nil {
+			if _, found := contextAlternatives[callee.String()]; found {
+				features.withoutContext = callee
+			}
+		}
 	}
 	return features
 }
 
+// isBlockingCall reports whether common calls a known or configured blocking
+// function, or a function with a body that makes such a call, following up
+// to depth calls.
+func (s *contextPropagationState) isBlockingCall(common *ssa.CallCommon, depth int) bool {
+	if looksLikeBlockingCall(common) || callsConfiguredFunc(common, s.blocking) {
+		return true
+	}
+	if depth == 0 || common.IsInvoke() {
+		return false
+	}
+	callee := common.StaticCallee()
+	if callee == nil || len(callee.Blocks) == 0 {
+		return false
+	}
+
+	key := blockingKey{fn: callee, depth: depth}
+	if blocks, found := s.blockers[key]; found {
+		return blocks
+	}
+	// Recursive calls are assumed not to block until proven otherwise
+	s.blockers[key] = false
+	for _, block := range callee.Blocks {
+		for _, instr := range block.Instrs {
+			// A goroutine started by the callee does not block it
+			call, ok := instr.(ssa.CallInstruction)
+			if _, isGo := instr.(*ssa.Go); !ok || isGo || call.Common() == nil {
+				continue
+			}
+			if s.isBlockingCall(call.Common(), depth-1) {
+				s.blockers[key] = true
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// callsConfiguredFunc reports whether common calls one of the functions
+// listed in the rule configuration, keyed by ssa.Function.String(). Interface
+// methods are matched by their declaring interface, e.g.
+// "(mycompany/rpc.Caller).Call".
+func callsConfiguredFunc(common *ssa.CallCommon, funcs map[string]struct{}) bool {
+	if len(funcs) == 0 {
+		return false
+	}
+	var key string
+	if common.IsInvoke() {
+		key = common.Method.FullName()
+	} else if callee := common.StaticCallee(); callee != nil {
+		key = callee.String()
+	}
+	_, found := funcs[key]
+	return key != "" && found
+}
+
 type loopRegion struct {
-	blocks          []*ssa.BasicBlock
-	hasExternalExit bool
-	pos             token.Pos
+	blocks            []*ssa.BasicBlock
+	hasExternalExit   bool
+	rangesOverChannel bool
+	pos               token.Pos
 }
 
 func findLoopRegions(fn *ssa.Function) []loopRegion {
@@ -389,16 +1021,23 @@ func findLoopRegions(fn *ssa.Function) []loopRegion {
 		}
 
 		hasExternalExit := false
+		rangesOverChannel := false
 		pos := token.NoPos
 		for _, b := range scc {
 			if pos == token.NoPos && len(b.Instrs) > 0 {
 				pos = b.Instrs[0].Pos()
 			}
+			// Leaving a range over a channel once it is closed depends on the
+			// sender, so it is not an exit the loop controls.
+			if isChannelRangeExit(b) {
+				rangesOverChannel = true
+				continue
+			}
 			for _, succ := range b.Succs {
 				if succ == nil {
 					continue
 				}
-				if !sccSet[succ] {
+				if !sccSet[succ] && !isSelectFallthrough(b, succ) {
 					hasExternalExit = true
 					break
 				}
@@ -418,9 +1057,10 @@ func findLoopRegions(fn *ssa.Function) []loopRegion {
 		}
 
 		regions = append(regions, loopRegion{
-			blocks:          scc,
-			hasExternalExit: hasExternalExit,
-			pos:             pos,
+			blocks:            scc,
+			hasExternalExit:   hasExternalExit,
+			rangesOverChannel: rangesOverChannel,
+			pos:               pos,
 		})
 	}
 
@@ -437,6 +1077,67 @@ func findLoopRegions(fn *ssa.Function) []loopRegion {
 	return regions
 }
 
+// selectReceivesFromDone reports whether one of the cases of sel receives
+// from the channel returned by a context's Done method.
+func selectReceivesFromDone(sel *ssa.Select) bool {
+	for _, state := range sel.States {
+		if state.Dir != types.RecvOnly {
+			continue
+		}
+		call, ok := state.Chan.(*ssa.Call)
+		if ok && isContextDoneCall(call.Common()) {
+			return true
+		}
+	}
+	return false
+}
+
+// isSelectFallthrough reports whether succ is the unreachable block that a
+// blocking select falls through to when none of the case indexes compared
+// in block matched.
+func isSelectFallthrough(block, succ *ssa.BasicBlock) bool {
+	if len(block.Instrs) == 0 || len(succ.Instrs) == 0 {
+		return false
+	}
+	if _, ok := succ.Instrs[len(succ.Instrs)-1].(*ssa.Panic); !ok {
+		return false
+	}
+	ifInstr, ok := block.Instrs[len(block.Instrs)-1].(*ssa.If)
+	if !ok {
+		return false
+	}
+	cmp, ok := ifInstr.Cond.(*ssa.BinOp)
+	if !ok || cmp.Op != token.EQL {
+		return false
+	}
+	index, ok := cmp.X.(*ssa.Extract)
+	if !ok || index.Index != 0 {
+		return false
+	}
+	sel, ok := index.Tuple.(*ssa.Select)
+	return ok && sel.Blocking
+}
+
+// isChannelRangeExit reports whether block ends the iteration of a range
+// over a channel, branching on the ok result of the receive:
+//
+//	for v := range ch { ... }
+func isChannelRangeExit(block *ssa.BasicBlock) bool {
+	if len(block.Instrs) == 0 {
+		return false
+	}
+	ifInstr, ok := block.Instrs[len(block.Instrs)-1].(*ssa.If)
+	if !ok {
+		return false
+	}
+	extract, ok := ifInstr.Cond.(*ssa.Extract)
+	if !ok || extract.Index != 1 {
+		return false
+	}
+	recv, ok := extract.Tuple.(*ssa.UnOp)
+	return ok && recv.Op == token.ARROW && recv.CommaOk
+}
+
 func isLoopSCC(scc []*ssa.BasicBlock, sccSet map[*ssa.BasicBlock]bool) bool {
 	if len(scc) > 1 {
 		return true
@@ -496,6 +1197,13 @@ func looksLikeBlockingCall(common *ssa.CallCommon) bool {
 		}
 	}
 
+	if pkgPath == "net" {
+		switch name {
+		case "Dial", "DialTimeout", "DialContext":
+			return true
+		}
+	}
+
 	if pkgPath == "os" {
 		switch name {
 		case "ReadFile", "WriteFile", "Open", "OpenFile":
@@ -506,13 +1214,23 @@ func looksLikeBlockingCall(common *ssa.CallCommon) bool {
 	return false
 }
 
-func resolveGoCallTargets(goInstr *ssa.Go) []*ssa.Function {
-	var funcs []*ssa.Function
-	if goInstr == nil {
-		return funcs
+// launchedFunc returns the function value that instr runs in a new goroutine:
+// the target of a go statement, or the function passed to the Go or TryGo
+// method of an errgroup.Group. It returns nil for any other instruction.
+func launchedFunc(instr ssa.Instruction) ssa.Value {
+	switch i := instr.(type) {
+	case *ssa.Go:
+		return i.Call.Value
+	case *ssa.Call:
+		if isErrgroupGoCall(i.Common()) && len(i.Call.Args) == 2 {
+			return i.Call.Args[1]
+		}
 	}
+	return nil
+}
 
-	value := goInstr.Call.Value
+func resolveFuncs(value ssa.Value) []*ssa.Function {
+	var funcs []*ssa.Function
 	if value == nil {
 		return funcs
 	}
@@ -555,6 +1273,34 @@ func functionCallsBackground(fn *ssa.Function) bool {
 	return false
 }
 
+// goroutineCallsBackground reports whether fn, or any goroutine it launches
+// directly or through further nested goroutines, creates a context with
+// context.Background or context.TODO.
+func goroutineCallsBackground(fn *ssa.Function, visited map[*ssa.Function]bool) bool {
+	if fn == nil || visited[fn] {
+		return false
+	}
+	visited[fn] = true
+
+	if functionCallsBackground(fn) {
+		return true
+	}
+	for _, block := range fn.Blocks {
+		for _, instr := range block.Instrs {
+			launched := launchedFunc(instr)
+			if launched == nil {
+				continue
+			}
+			for _, callee := range resolveFuncs(launched) {
+				if goroutineCallsBackground(callee, visited) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
 func isBackgroundOrTodoValue(v ssa.Value) bool {
 	call, ok := v.(*ssa.Call)
 	if !ok {
@@ -601,6 +1347,47 @@ func isContextWithFamily(common *ssa.CallCommon) bool {
 	}
 }
 
+func isNewTimerCall(common *ssa.CallCommon) bool {
+	callee := common.StaticCallee()
+	if callee == nil || callee.Pkg == nil || callee.Pkg.Pkg == nil {
+		return false
+	}
+	if callee.Pkg.Pkg.Path() != "time" {
+		return false
+	}
+	return callee.Name() == "NewTicker" || callee.Name() == "NewTimer"
+}
+
+func isErrgroupWithContext(common *ssa.CallCommon) bool {
+	callee := common.StaticCallee()
+	if callee == nil || callee.Pkg == nil || callee.Pkg.Pkg == nil {
+		return false
+	}
+	return callee.Pkg.Pkg.Path() == errgroupPkgPath && callee.Name() == "WithContext" && callee.Signature.Recv() == nil
+}
+
+func isErrgroupGoCall(common *ssa.CallCommon) bool {
+	if common == nil {
+		return false
+	}
+	callee := common.StaticCallee()
+	if callee == nil || callee.Signature.Recv() == nil {
+		return false
+	}
+	if callee.Name() != "Go" && callee.Name() != "TryGo" {
+		return false
+	}
+	ptr, ok := callee.Signature.Recv().Type().(*types.Pointer)
+	if !ok {
+		return false
+	}
+	named, ok := ptr.Elem().(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return false
+	}
+	return named.Obj().Pkg().Path() == errgroupPkgPath && named.Obj().Name() == "Group"
+}
+
 func isHTTPRequestContextCall(common *ssa.CallCommon) bool {
 	if common == nil || common.IsInvoke() {
 		return false
@@ -673,7 +1460,7 @@ func isCancelFuncType(t types.Type) bool {
 	return true
 }
 
-func isCancelCalled(cancelValue ssa.Value, allFuncs []*ssa.Function) bool {
+func isCancelCalled(cancelValue ssa.Value, allFuncs []*ssa.Function, owners map[string]struct{}) bool {
 	if cancelValue == nil {
 		return false
 	}
@@ -718,6 +1505,16 @@ func isCancelCalled(cancelValue ssa.Value, allFuncs []*ssa.Function) bool {
 					if isFieldCalledInAnyFunc(fa, allFuncs) {
 						return true
 					}
+					// Check if the struct is handed to a configured owner,
+					// e.g. lifecycle.Register(&Hook{Stop: cancel}).
+					if isPassedToOwner(fa.X, owners) {
+						return true
+					}
+				}
+				// Check if cancel is one of the variadic arguments of a
+				// call, e.g. cleanup.Add(cancel) with Add(fns ...func()).
+				if ia, ok := r.Addr.(*ssa.IndexAddr); ok && isVariadicArgument(ia) {
+					return true
 				}
 				// Check if storing to a package-level global variable.
 				// When cancel is stored to a global (e.g., in init()), we need
@@ -777,6 +1574,45 @@ func isCancelCalled(cancelValue ssa.Value, allFuncs []*ssa.Function) bool {
 // is loaded and returned from the enclosing function. When a cancel is stored in
 // a struct field and the struct is returned, responsibility for calling the
 // cancel is transferred to the caller.
+// isVariadicArgument reports whether addr is an element of the slice that
+// is built to pass variadic arguments to a call.
+func isVariadicArgument(addr *ssa.IndexAddr) bool {
+	alloc, ok := addr.X.(*ssa.Alloc)
+	if !ok {
+		return false
+	}
+	for _, ref := range safeReferrers(alloc) {
+		slice, ok := ref.(*ssa.Slice)
+		if !ok {
+			continue
+		}
+		for _, sliceRef := range safeReferrers(slice) {
+			if call, ok := sliceRef.(ssa.CallInstruction); ok && isUsedInCall(call.Common(), slice) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isPassedToOwner reports whether v is passed to one of the configured
+// functions that take ownership of the cancel functions it holds.
+func isPassedToOwner(v ssa.Value, owners map[string]struct{}) bool {
+	if len(owners) == 0 {
+		return false
+	}
+	for _, ref := range safeReferrers(v) {
+		call, ok := ref.(ssa.CallInstruction)
+		if !ok {
+			continue
+		}
+		if common := call.Common(); callsConfiguredFunc(common, owners) && isUsedInCall(common, v) {
+			return true
+		}
+	}
+	return false
+}
+
 func isStructFieldReturnedFromFunc(fa *ssa.FieldAddr) bool {
 	structBase := fa.X
 	if structBase == nil {
@@ -0,0 +1,262 @@
+package analyzers_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/securego/gosec/v2"
+)
+
+// rpcModule polls an in-house RPC client forever without a ctx.Done guard.
+var rpcModule = map[string]string{
+	"go.mod": "module mycompany\n\ngo 1.25\n",
+	"rpc/rpc.go": `package rpc
+
+type Client struct {
+	addr string
+}
+
+func (c *Client) Call(method string, reply *string) error {
+	*reply = c.addr + "/" + method
+	return nil
+}
+`,
+	"app/app.go": `package app
+
+import (
+	"context"
+
+	"mycompany/rpc"
+)
+
+func Watch(ctx context.Context, c *rpc.Client, updates chan<- string) {
+	for {
+		var reply string
+		if err := c.Call("Status.Get", &reply); err == nil {
+			updates <- reply
+		}
+	}
+}
+`,
+}
+
+// lifecycleModule hands cancel functions to a registry in another package,
+// which calls them on shutdown.
+var lifecycleModule = map[string]string{
+	"go.mod": "module mycompany\n\ngo 1.25\n",
+	"lifecycle/lifecycle.go": `package lifecycle
+
+type Hook struct {
+	Name string
+	Stop func()
+}
+
+var hooks []*Hook
+
+func Register(h *Hook) {
+	hooks = append(hooks, h)
+}
+
+func Shutdown() {
+	for _, h := range hooks {
+		h.Stop()
+	}
+}
+`,
+	"app/app.go": `package app
+
+import (
+	"context"
+
+	"mycompany/lifecycle"
+)
+
+func Start(ctx context.Context) context.Context {
+	child, cancel := context.WithCancel(ctx)
+	lifecycle.Register(&lifecycle.Hook{Name: "poller", Stop: cancel})
+	return child
+}
+`,
+}
+
+// helperModule polls through two levels of helpers before reaching a
+// blocking call.
+var helperModule = map[string]string{
+	"go.mod": "module mycompany\n\ngo 1.25\n",
+	"app/app.go": `package app
+
+import (
+	"context"
+	"net/http"
+)
+
+func fetch(url string) {
+	resp, err := http.Get(url)
+	if err == nil {
+		resp.Body.Close()
+	}
+}
+
+func refresh() {
+	fetch("https://api.example.com/status")
+}
+
+func Watch(ctx context.Context) {
+	for {
+		refresh()
+	}
+}
+`,
+}
+
+var _ = Describe("context propagation configuration", func() {
+	It("should not know about in-house blocking calls by default", func() {
+		issues, err := analyzeModule("G118", gosec.NewConfig(), rpcModule, "app")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(issues).Should(BeEmpty())
+	})
+
+	It("should flag unguarded loops around configured blocking calls", func() {
+		config := gosec.NewConfig()
+		config.Set("G118", map[string]interface{}{
+			"blocking": []interface{}{"mycompany/rpc.(*Client).Call"},
+		})
+		issues, err := analyzeModule("G118", config, rpcModule, "app")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(issues).Should(HaveLen(1))
+		Expect(issues[0].RuleID).Should(Equal("G118"))
+	})
+
+	It("should follow one call into helpers by default", func() {
+		issues, err := analyzeModule("G118", gosec.NewConfig(), helperModule, "app")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(issues).Should(BeEmpty())
+	})
+
+	It("should follow the configured number of calls into helpers", func() {
+		config := gosec.NewConfig()
+		config.Set("G118", map[string]interface{}{
+			"blocking_depth": float64(2),
+		})
+		issues, err := analyzeModule("G118", config, helperModule, "app")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(issues).Should(HaveLen(1))
+		Expect(issues[0].What).Should(ContainSubstring("ctx.Done()"))
+	})
+
+	It("should not know about cancel owners in other packages by default", func() {
+		issues, err := analyzeModule("G118", gosec.NewConfig(), lifecycleModule, "app")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(issues).Should(HaveLen(1))
+	})
+
+	It("should accept cancel functions handed to configured owners", func() {
+		config := gosec.NewConfig()
+		config.Set("G118", map[string]interface{}{
+			"cancel_owners": []interface{}{"mycompany/lifecycle.Register"},
+		})
+		issues, err := analyzeModule("G118", config, lifecycleModule, "app")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(issues).Should(BeEmpty())
+	})
+})
+
+// cancelModule returns a module with the given function in package app.
+func cancelModule(fn string) map[string]string {
+	return map[string]string{
+		"go.mod": "module mycompany\n\ngo 1.25\n",
+		"app/app.go": `package app
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+var (
+	_ = time.Second
+	_ *sql.DB
+)
+
+` + fn,
+	}
+}
+
+var _ = Describe("context propagation autofix", func() {
+	autofix := func(fn string) string {
+		issues, err := analyzeModule("G118", gosec.NewConfig(), cancelModule(fn), "app")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(issues).Should(HaveLen(1))
+		return issues[0].Autofix
+	}
+
+	It("should suggest deferring a cancel function that is only assigned to blank", func() {
+		Expect(autofix(`func storeCancel(ctx context.Context) {
+	_, cancel := context.WithCancel(ctx)
+	_ = cancel
+}
+`)).Should(Equal("Defer the cancel function right after the context is created:\n\t_, cancel := context.WithCancel(ctx)\n\tdefer cancel()"))
+	})
+
+	It("should suggest naming and deferring a dropped cancel function", func() {
+		Expect(autofix(`func dropCancel(ctx context.Context) context.Context {
+	child, _ := context.WithTimeout(ctx, time.Second)
+	return child
+}
+`)).Should(Equal("Defer the cancel function right after the context is created:\n\tchild, cancel := context.WithTimeout(ctx, time.Second)\n\tdefer cancel()"))
+	})
+
+	It("should suggest deferring a cancel function skipped by an early return", func() {
+		Expect(autofix(`func earlyReturn(ctx context.Context, db *sql.DB) error {
+	child, cancel := context.WithTimeout(ctx, time.Second)
+	if db == nil {
+		return context.Canceled
+	}
+	defer cancel()
+	return db.PingContext(child)
+}
+`)).Should(Equal("Defer the cancel function right after the context is created, before any return"))
+	})
+
+	It("should suggest the context-aware variant of calls in unguarded loops", func() {
+		Expect(autofix(`func worker(ctx context.Context, db *sql.DB) {
+	for {
+		rows, err := db.Query("SELECT id FROM jobs")
+		if err == nil {
+			rows.Close()
+		}
+	}
+}
+`)).Should(Equal("Call (*sql.DB).QueryContext instead of (*database/sql.DB).Query so that it can be canceled, and return from the loop once ctx.Done() is closed"))
+	})
+
+	It("should not suggest a fix in a loop", func() {
+		Expect(autofix(`func leakyLoop(ctx context.Context) {
+	for i := 0; i < 10; i++ {
+		child, _ := context.WithTimeout(ctx, time.Second)
+		_ = child
+	}
+}
+`)).Should(BeEmpty())
+	})
+
+	It("should not suggest a fix when the cancel function is used elsewhere", func() {
+		Expect(autofix(`func renameCancel(ctx context.Context) {
+	_, cancel := context.WithCancel(ctx)
+	stop := cancel
+	_ = stop
+}
+`)).Should(BeEmpty())
+	})
+
+	It("should not suggest a name that is already in use", func() {
+		Expect(autofix(`func cancel() {}
+
+func shadowCancel(ctx context.Context) {
+	child, _ := context.WithCancel(ctx)
+	_ = child
+	cancel()
+}
+`)).Should(BeEmpty())
+	})
+})
@@ -0,0 +1,53 @@
+// (c) Copyright gosec's authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyzers
+
+import (
+	"slices"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/securego/gosec/v2/taint"
+)
+
+// EnvInjection returns a configuration for detecting user input written to
+// environment variables, either of the current process or of a child started
+// with os/exec. Variables such as LD_PRELOAD, PATH or GIT_SSH_COMMAND change
+// what the program or its children execute. See CWE-15.
+func EnvInjection() taint.Config {
+	return taint.Config{
+		Sources: slices.Clone(sqlInjectionSources),
+		Sinks: []taint.Sink{
+			// Only the value is checked: variable names are almost always
+			// constants, and the value is what the input controls.
+			{Package: "os", Method: "Setenv", CheckArgs: []int{1}},
+			{Package: "syscall", Method: "Setenv", CheckArgs: []int{1}},
+			// The environment of a child process, whether set by assignment
+			// or in a composite literal.
+			{Package: "os/exec", Receiver: "Cmd", Method: "Env", Field: true},
+		},
+		Sanitizers: slices.Clone(numericSanitizers),
+	}
+}
+
+// newEnvInjectionAnalyzer creates an analyzer for detecting environment
+// variable injection via taint analysis (G714).
+func newEnvInjectionAnalyzer(id string, description string) *analysis.Analyzer {
+	config := EnvInjection()
+	rule := EnvInjectionRule
+	rule.ID = id
+	rule.Description = description
+	return taint.NewGosecAnalyzer(&rule, &config)
+}
@@ -0,0 +1,56 @@
+// (c) Copyright gosec's authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyzers
+
+import (
+	"slices"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/securego/gosec/v2/taint"
+)
+
+// FormatString returns a configuration for detecting externally-controlled
+// format strings passed to the fmt printing functions. See CWE-134.
+//
+// Only the format argument is a sink: tainted values passed as data are
+// formatted by the verbs of a constant format and cannot inject verbs of
+// their own, so fmt.Sprintf("%s", tainted) is not reported.
+func FormatString() taint.Config {
+	return taint.Config{
+		Sources: slices.Clone(sqlInjectionSources),
+		Sinks: []taint.Sink{
+			// The format is the first argument...
+			{Package: "fmt", Method: "Printf", CheckArgs: []int{0}},
+			{Package: "fmt", Method: "Sprintf", CheckArgs: []int{0}},
+			{Package: "fmt", Method: "Errorf", CheckArgs: []int{0}},
+			// ...or follows the destination writer or buffer.
+			{Package: "fmt", Method: "Fprintf", CheckArgs: []int{1}},
+			{Package: "fmt", Method: "Appendf", CheckArgs: []int{1}},
+		},
+		// A numeric string cannot contain a formatting verb.
+		Sanitizers: slices.Clone(numericSanitizers),
+	}
+}
+
+// newFormatStringAnalyzer creates an analyzer for detecting tainted format
+// strings via taint analysis (G715).
+func newFormatStringAnalyzer(id string, description string) *analysis.Analyzer {
+	config := FormatString()
+	rule := FormatStringRule
+	rule.ID = id
+	rule.Description = description
+	return taint.NewGosecAnalyzer(&rule, &config)
+}
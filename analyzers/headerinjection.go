@@ -0,0 +1,55 @@
+// (c) Copyright gosec's authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyzers
+
+import (
+	"slices"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/securego/gosec/v2/taint"
+)
+
+// HeaderInjection returns a configuration for detecting user input written
+// to HTTP header values, where CR and LF characters can split the header and
+// inject headers or a body of their own. See CWE-113.
+func HeaderInjection() taint.Config {
+	sanitizers := slices.Clone(numericSanitizers)
+	// Removing both line terminators, in either order, leaves nothing to
+	// split the header on; stripping only one of them does not.
+	sanitizers = append(sanitizers,
+		taint.Sanitizer{Package: "strings", Method: "ReplaceAll", Strips: []string{"\r", "\n"}},
+	)
+	return taint.Config{
+		Sources: slices.Clone(sqlInjectionSources),
+		Sinks: []taint.Sink{
+			// Only the value is checked: header names are canonicalized and
+			// rarely built from input.
+			{Package: "net/http", Receiver: "Header", Method: "Set", CheckArgs: []int{2}},
+			{Package: "net/http", Receiver: "Header", Method: "Add", CheckArgs: []int{2}},
+		},
+		Sanitizers: sanitizers,
+	}
+}
+
+// newHeaderInjectionAnalyzer creates an analyzer for detecting HTTP header
+// injection via taint analysis (G716).
+func newHeaderInjectionAnalyzer(id string, description string) *analysis.Analyzer {
+	config := HeaderInjection()
+	rule := HeaderInjectionRule
+	rule.ID = id
+	rule.Description = description
+	return taint.NewGosecAnalyzer(&rule, &config)
+}
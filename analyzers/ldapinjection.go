@@ -0,0 +1,65 @@
+// (c) Copyright gosec's authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyzers
+
+import (
+	"slices"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/securego/gosec/v2/taint"
+)
+
+// ldapPackages are the import paths of the go-ldap client.
+var ldapPackages = []string{
+	"github.com/go-ldap/ldap/v3",
+	"github.com/go-ldap/ldap",
+	"gopkg.in/ldap.v3",
+	"gopkg.in/ldap.v2",
+}
+
+// LDAPInjection returns a configuration for detecting user input in LDAP
+// search filters. See CWE-90.
+//
+// The client is matched by import path, so it is not a dependency of gosec.
+// A value escaped with ldap.EscapeFilter cannot close the filter or add
+// wildcards, so it is safe to concatenate.
+func LDAPInjection() taint.Config {
+	var sinks []taint.Sink
+	sanitizers := slices.Clone(numericSanitizers)
+	for _, pkg := range ldapPackages {
+		sinks = append(sinks,
+			// NewSearchRequest(baseDN, scope, derefAliases, sizeLimit, timeLimit, typesOnly, filter, attributes, controls)
+			taint.Sink{Package: pkg, Method: "NewSearchRequest", CheckArgs: []int{6}},
+			taint.Sink{Package: pkg, Method: "CompileFilter", CheckArgs: []int{0}},
+		)
+		sanitizers = append(sanitizers, taint.Sanitizer{Package: pkg, Method: "EscapeFilter"})
+	}
+	return taint.Config{
+		Sources:    slices.Clone(sqlInjectionSources),
+		Sinks:      sinks,
+		Sanitizers: sanitizers,
+	}
+}
+
+// newLDAPInjectionAnalyzer creates an analyzer for detecting LDAP injection
+// via taint analysis (G713).
+func newLDAPInjectionAnalyzer(id string, description string) *analysis.Analyzer {
+	config := LDAPInjection()
+	rule := LDAPInjectionRule
+	rule.ID = id
+	rule.Description = description
+	return taint.NewGosecAnalyzer(&rule, &config)
+}
@@ -46,6 +46,16 @@ func LogInjection() taint.Config {
 			{Package: "log", Method: "Panic"},
 			{Package: "log", Method: "Panicf"},
 			{Package: "log", Method: "Panicln"},
+			// Methods on a *log.Logger take the same arguments after the receiver
+			{Package: "log", Receiver: "Logger", Method: "Print", Pointer: true},
+			{Package: "log", Receiver: "Logger", Method: "Printf", Pointer: true},
+			{Package: "log", Receiver: "Logger", Method: "Println", Pointer: true},
+			{Package: "log", Receiver: "Logger", Method: "Fatal", Pointer: true},
+			{Package: "log", Receiver: "Logger", Method: "Fatalf", Pointer: true},
+			{Package: "log", Receiver: "Logger", Method: "Fatalln", Pointer: true},
+			{Package: "log", Receiver: "Logger", Method: "Panic", Pointer: true},
+			{Package: "log", Receiver: "Logger", Method: "Panicf", Pointer: true},
+			{Package: "log", Receiver: "Logger", Method: "Panicln", Pointer: true},
 			// log/slog structured logging functions have the signature:
 			//   func Warn(msg string, args ...any)
 			// The variadic `args` are key-value attribute pairs whose values are
@@ -59,6 +69,20 @@ func LogInjection() taint.Config {
 			{Package: "log/slog", Method: "Warn", CheckArgs: []int{0}},
 			{Package: "log/slog", Method: "Error", CheckArgs: []int{0}},
 			{Package: "log/slog", Method: "Debug", CheckArgs: []int{0}},
+			// The Context variants take the message after the context.
+			{Package: "log/slog", Method: "InfoContext", CheckArgs: []int{1}},
+			{Package: "log/slog", Method: "WarnContext", CheckArgs: []int{1}},
+			{Package: "log/slog", Method: "ErrorContext", CheckArgs: []int{1}},
+			{Package: "log/slog", Method: "DebugContext", CheckArgs: []int{1}},
+			// Methods on a *slog.Logger: Args[0] is the receiver.
+			{Package: "log/slog", Receiver: "Logger", Method: "Info", Pointer: true, CheckArgs: []int{1}},
+			{Package: "log/slog", Receiver: "Logger", Method: "Warn", Pointer: true, CheckArgs: []int{1}},
+			{Package: "log/slog", Receiver: "Logger", Method: "Error", Pointer: true, CheckArgs: []int{1}},
+			{Package: "log/slog", Receiver: "Logger", Method: "Debug", Pointer: true, CheckArgs: []int{1}},
+			{Package: "log/slog", Receiver: "Logger", Method: "InfoContext", Pointer: true, CheckArgs: []int{2}},
+			{Package: "log/slog", Receiver: "Logger", Method: "WarnContext", Pointer: true, CheckArgs: []int{2}},
+			{Package: "log/slog", Receiver: "Logger", Method: "ErrorContext", Pointer: true, CheckArgs: []int{2}},
+			{Package: "log/slog", Receiver: "Logger", Method: "DebugContext", Pointer: true, CheckArgs: []int{2}},
 		},
 		Sanitizers: []taint.Sanitizer{
 			// strings.ReplaceAll can strip newlines/CRLF for log injection
@@ -0,0 +1,69 @@
+// (c) Copyright gosec's authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyzers
+
+import (
+	"slices"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/securego/gosec/v2/taint"
+)
+
+// mongoCodeKeys are the query operators whose operand is evaluated as
+// JavaScript or as an aggregation expression rather than compared as data.
+var mongoCodeKeys = []string{"$where", "$expr", "$function", "$accumulator"}
+
+// NoSQLInjection returns a configuration for detecting user input that
+// changes the logic of MongoDB queries. See CWE-943.
+//
+// The driver is matched by import path, so it is not a dependency of gosec.
+// A filter literal such as bson.M{"_id": id} is safe with a tainted id:
+// the driver sends it as a typed value. It is only reported when a tainted
+// value is the operand of one of mongoCodeKeys, is itself a key, or is a
+// document of unknown shape, such as one decoded from the request body.
+func NoSQLInjection() taint.Config {
+	var sinks []taint.Sink
+	for _, pkg := range []string{"go.mongodb.org/mongo-driver/mongo", "go.mongodb.org/mongo-driver/v2/mongo"} {
+		// The filter or pipeline follows the context
+		for _, method := range []string{
+			"Find", "FindOne", "FindOneAndDelete", "FindOneAndReplace", "FindOneAndUpdate",
+			"CountDocuments", "DeleteOne", "DeleteMany", "UpdateOne", "UpdateMany", "ReplaceOne",
+			"Aggregate", "Watch",
+		} {
+			sinks = append(sinks, taint.Sink{Package: pkg, Receiver: "Collection", Method: method, Pointer: true, CheckArgs: []int{2}, DocumentKeys: mongoCodeKeys})
+		}
+		// Distinct(ctx, fieldName, filter)
+		sinks = append(sinks, taint.Sink{Package: pkg, Receiver: "Collection", Method: "Distinct", Pointer: true, CheckArgs: []int{3}, DocumentKeys: mongoCodeKeys})
+		for _, method := range []string{"Aggregate", "RunCommand", "RunCommandCursor", "Watch"} {
+			sinks = append(sinks, taint.Sink{Package: pkg, Receiver: "Database", Method: method, Pointer: true, CheckArgs: []int{2}, DocumentKeys: mongoCodeKeys})
+		}
+	}
+	return taint.Config{
+		Sources:    slices.Clone(sqlInjectionSources),
+		Sinks:      sinks,
+		Sanitizers: slices.Clone(numericSanitizers),
+	}
+}
+
+// newNoSQLInjectionAnalyzer creates an analyzer for detecting NoSQL
+// injection via taint analysis (G711).
+func newNoSQLInjectionAnalyzer(id string, description string) *analysis.Analyzer {
+	config := NoSQLInjection()
+	rule := NoSQLInjectionRule
+	rule.ID = id
+	rule.Description = description
+	return taint.NewGosecAnalyzer(&rule, &config)
+}
@@ -38,6 +38,11 @@ func OpenRedirect() taint.Config {
 			// is the redirect target. Skipping arg 1 (*http.Request) prevents the
 			// receiver itself from being treated as a tainted sink argument.
 			{Package: "net/http", Method: "Redirect", CheckArgs: []int{2}},
+
+			// w.Header().Set("Location", url) redirects as well once a 3xx
+			// status is written; other headers are not redirect targets.
+			{Package: "net/http", Receiver: "Header", Method: "Set", CheckArgs: []int{2}, ArgValues: map[int]string{1: "Location"}},
+			{Package: "net/http", Receiver: "Header", Method: "Add", CheckArgs: []int{2}, ArgValues: map[int]string{1: "Location"}},
 		},
 		Sanitizers: []taint.Sanitizer{
 			// url.PathEscape / QueryEscape neutralize untrusted path or query
@@ -53,6 +58,16 @@ func OpenRedirect() taint.Config {
 			{Package: "strconv", Method: "FormatInt"},
 			{Package: "strconv", Method: "FormatUint"},
 		},
+		// A target parsed with url.Parse that has no host is a path on the
+		// same site, and one whose host is checked against a fixed allowlist
+		// can only lead to the allowed sites:
+		//	u, err := url.Parse(next)
+		//	if err != nil || u.Host != "" { return }
+		//	http.Redirect(w, r, next, http.StatusFound)
+		AllowlistKeys: []taint.AllowlistKey{
+			{Package: "net/url", Type: "URL", Field: "Host"},
+			{Package: "net/url", Type: "URL", Field: "Hostname"},
+		},
 	}
 }
 
@@ -41,26 +41,28 @@ func PathTraversal() taint.Config {
 			// then reads from it, the taint flows through the path argument, not the
 			// File type itself.
 		},
+		// Only the path argument is checked where a sink also takes file
+		// contents, permissions or a callback.
 		Sinks: []taint.Sink{
 			{Package: "os", Method: "Open"},
-			{Package: "os", Method: "OpenFile"},
+			{Package: "os", Method: "OpenFile", CheckArgs: []int{0}},
 			{Package: "os", Method: "Create"},
 			{Package: "os", Method: "ReadFile"},
-			{Package: "os", Method: "WriteFile"},
+			{Package: "os", Method: "WriteFile", CheckArgs: []int{0}},
 			{Package: "os", Method: "Remove"},
 			{Package: "os", Method: "RemoveAll"},
 			{Package: "os", Method: "Rename"},
-			{Package: "os", Method: "Mkdir"},
-			{Package: "os", Method: "MkdirAll"},
+			{Package: "os", Method: "Mkdir", CheckArgs: []int{0}},
+			{Package: "os", Method: "MkdirAll", CheckArgs: []int{0}},
 			{Package: "os", Method: "Stat"},
 			{Package: "os", Method: "Lstat"},
-			{Package: "os", Method: "Chmod"},
-			{Package: "os", Method: "Chown"},
+			{Package: "os", Method: "Chmod", CheckArgs: []int{0}},
+			{Package: "os", Method: "Chown", CheckArgs: []int{0}},
 			{Package: "io/ioutil", Method: "ReadFile"},
-			{Package: "io/ioutil", Method: "WriteFile"},
+			{Package: "io/ioutil", Method: "WriteFile", CheckArgs: []int{0}},
 			{Package: "io/ioutil", Method: "ReadDir"},
-			{Package: "path/filepath", Method: "Walk"},
-			{Package: "path/filepath", Method: "WalkDir"},
+			{Package: "path/filepath", Method: "Walk", CheckArgs: []int{0}},
+			{Package: "path/filepath", Method: "WalkDir", CheckArgs: []int{0}},
 			// HTTP file-serving functions: user-controlled path = arbitrary file read
 			{Package: "net/http", Method: "ServeFile", CheckArgs: []int{2}},
 			{Package: "net/http", Method: "ServeFileFS", CheckArgs: []int{3}},
@@ -91,9 +93,28 @@ func PathTraversal() taint.Config {
 			{Package: "strconv", Method: "ParseFloat"},
 			{Package: "strconv", Method: "ParseBool"},
 		},
+		Guards: []taint.Guard{
+			// A normalized path checked against the base directory:
+			//	p := filepath.Join(baseDir, name)
+			//	if !strings.HasPrefix(p, baseDir+string(filepath.Separator)) { return }
+			{Package: "strings", Method: "HasPrefix", Normalizers: pathNormalizers},
+			// A normalized path rejected when it still contains "..":
+			//	if strings.Contains(p, "..") { return }
+			{Package: "strings", Method: "Contains", Negated: true, Arg: "..", Normalizers: pathNormalizers},
+		},
 	}
 }
 
+// pathNormalizers resolve "." and ".." elements, so that checking their result
+// reflects the file that is actually accessed.
+var pathNormalizers = []taint.Sanitizer{
+	{Package: "path/filepath", Method: "Clean"},
+	{Package: "path/filepath", Method: "Join"},
+	{Package: "path/filepath", Method: "Abs"},
+	{Package: "path", Method: "Clean"},
+	{Package: "path", Method: "Join"},
+}
+
 // newPathTraversalAnalyzer creates an analyzer for detecting path traversal vulnerabilities
 // via taint analysis (G703)
 func newPathTraversalAnalyzer(id string, description string) *analysis.Analyzer {
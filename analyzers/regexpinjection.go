@@ -0,0 +1,60 @@
+// (c) Copyright gosec's authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyzers
+
+import (
+	"slices"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/securego/gosec/v2/taint"
+)
+
+// RegexpInjection returns a configuration for detecting regular expressions
+// compiled from user input. See CWE-1333.
+//
+// Go's regexp package matches in linear time, but a pattern chosen by the
+// caller can still be large or repetitive enough to make compiling and
+// matching it expensive, and it changes what the program accepts. Only the
+// pattern is a sink: the text matched against a constant pattern is data.
+func RegexpInjection() taint.Config {
+	sanitizers := slices.Clone(numericSanitizers)
+	// A quoted pattern matches its text literally.
+	sanitizers = append(sanitizers, taint.Sanitizer{Package: "regexp", Method: "QuoteMeta"})
+	return taint.Config{
+		Sources: slices.Clone(sqlInjectionSources),
+		Sinks: []taint.Sink{
+			{Package: "regexp", Method: "Compile", CheckArgs: []int{0}},
+			{Package: "regexp", Method: "CompilePOSIX", CheckArgs: []int{0}},
+			{Package: "regexp", Method: "MustCompile", CheckArgs: []int{0}},
+			{Package: "regexp", Method: "MustCompilePOSIX", CheckArgs: []int{0}},
+			{Package: "regexp", Method: "Match", CheckArgs: []int{0}},
+			{Package: "regexp", Method: "MatchString", CheckArgs: []int{0}},
+			{Package: "regexp", Method: "MatchReader", CheckArgs: []int{0}},
+			{Package: "regexp/syntax", Method: "Parse", CheckArgs: []int{0}},
+		},
+		Sanitizers: sanitizers,
+	}
+}
+
+// newRegexpInjectionAnalyzer creates an analyzer for detecting regular
+// expressions compiled from user input via taint analysis (G712).
+func newRegexpInjectionAnalyzer(id string, description string) *analysis.Analyzer {
+	config := RegexpInjection()
+	rule := RegexpInjectionRule
+	rule.ID = id
+	rule.Description = description
+	return taint.NewGosecAnalyzer(&rule, &config)
+}
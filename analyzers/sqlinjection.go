@@ -15,26 +15,116 @@
 package analyzers
 
 import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
 	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/types/typeutil"
 
 	"github.com/securego/gosec/v2/taint"
 )
 
+// sqlInjectionSources lists the origins of untrusted data for G701, also used
+// by G702.
+// Adding a new source only requires appending an entry here.
+var sqlInjectionSources = []taint.Source{
+	// Type sources: tainted when received as parameters
+	{Package: "net/http", Name: "Request", Pointer: true},
+	{Package: "net/url", Name: "URL", Pointer: true},
+	{Package: "net/url", Name: "Values"},
+	{Package: "net/http", Name: "Header"},
+	{Package: "net/http", Name: "Cookie", Pointer: true},
+	{Package: "bufio", Name: "Reader", Pointer: true},
+	{Package: "bufio", Name: "Scanner", Pointer: true},
+
+	// Function sources
+	{Package: "os", Name: "Args", IsFunc: true},
+	{Package: "os", Name: "Getenv", IsFunc: true},
+	{Package: "os", Name: "LookupEnv", IsFunc: true},
+	{Package: "flag", Name: "String", IsFunc: true},
+	{Package: "flag", Name: "StringVar", IsFunc: true},
+	{Package: "flag", Name: "Arg", IsFunc: true},
+	{Package: "flag", Name: "Args", IsFunc: true},
+
+	// File contents may be attacker-controlled in multi-tenant deployments
+	{Package: "os", Name: "ReadFile", IsFunc: true},
+	{Package: "io/ioutil", Name: "ReadFile", IsFunc: true},
+
+	// Standard input: readers and scanners wrapping os.Stdin inherit its taint,
+	// so (*bufio.Scanner).Text and (*bufio.Reader).ReadString are tainted too.
+	{Package: "os", Name: "Stdin", IsFunc: true},
+
+	// Query values read from a url.Values of any origin, such as one parsed
+	// from a stored URL. Index reads of a url.Values parameter are covered by
+	// the Values type above.
+	{Package: "net/url", Receiver: "Values", Name: "Get", IsFunc: true},
+
+	// Web framework accessors are matched by method signature only, so gosec
+	// does not depend on the frameworks themselves.
+	// gin: methods on *gin.Context
+	{Package: "github.com/gin-gonic/gin", Receiver: "Context", Name: "Query", Pointer: true, IsFunc: true},
+	{Package: "github.com/gin-gonic/gin", Receiver: "Context", Name: "DefaultQuery", Pointer: true, IsFunc: true},
+	{Package: "github.com/gin-gonic/gin", Receiver: "Context", Name: "GetQuery", Pointer: true, IsFunc: true},
+	{Package: "github.com/gin-gonic/gin", Receiver: "Context", Name: "QueryArray", Pointer: true, IsFunc: true},
+	{Package: "github.com/gin-gonic/gin", Receiver: "Context", Name: "Param", Pointer: true, IsFunc: true},
+	{Package: "github.com/gin-gonic/gin", Receiver: "Context", Name: "PostForm", Pointer: true, IsFunc: true},
+	{Package: "github.com/gin-gonic/gin", Receiver: "Context", Name: "DefaultPostForm", Pointer: true, IsFunc: true},
+	{Package: "github.com/gin-gonic/gin", Receiver: "Context", Name: "GetPostForm", Pointer: true, IsFunc: true},
+	{Package: "github.com/gin-gonic/gin", Receiver: "Context", Name: "GetHeader", Pointer: true, IsFunc: true},
+	{Package: "github.com/gin-gonic/gin", Receiver: "Context", Name: "Cookie", Pointer: true, IsFunc: true},
+	// echo: methods on the echo.Context interface (v4 module path)
+	{Package: "github.com/labstack/echo/v4", Receiver: "Context", Name: "QueryParam", IsFunc: true},
+	{Package: "github.com/labstack/echo/v4", Receiver: "Context", Name: "QueryParams", IsFunc: true},
+	{Package: "github.com/labstack/echo/v4", Receiver: "Context", Name: "QueryString", IsFunc: true},
+	{Package: "github.com/labstack/echo/v4", Receiver: "Context", Name: "Param", IsFunc: true},
+	{Package: "github.com/labstack/echo/v4", Receiver: "Context", Name: "ParamValues", IsFunc: true},
+	{Package: "github.com/labstack/echo/v4", Receiver: "Context", Name: "FormValue", IsFunc: true},
+	{Package: "github.com/labstack/echo/v4", Receiver: "Context", Name: "FormParams", IsFunc: true},
+	// gorilla/mux: the map of path variables of a request
+	{Package: "github.com/gorilla/mux", Name: "Vars", IsFunc: true},
+	// chi: path parameters of a request (v5 and earlier module paths)
+	{Package: "github.com/go-chi/chi/v5", Name: "URLParam", IsFunc: true},
+	{Package: "github.com/go-chi/chi", Name: "URLParam", IsFunc: true},
+}
+
+// numericSanitizers are numeric conversions: their result is a plain number
+// and can never carry metacharacters, so round trips such as Atoi+Itoa clear
+// taint. They are shared by the injection rules.
+var numericSanitizers = []taint.Sanitizer{
+	{Package: "strconv", Method: "Atoi"},
+	{Package: "strconv", Method: "ParseInt"},
+	{Package: "strconv", Method: "ParseUint"},
+	{Package: "strconv", Method: "ParseFloat"},
+	{Package: "strconv", Method: "ParseBool"},
+	{Package: "strconv", Method: "Itoa"},
+	{Package: "strconv", Method: "FormatInt"},
+	{Package: "strconv", Method: "FormatUint"},
+	{Package: "strconv", Method: "FormatFloat"},
+	{Package: "strconv", Method: "FormatBool"},
+}
+
+// sqlInjectionSanitizers lists the functions whose results are safe to embed
+// in a query even when their input is tainted. There is no general-purpose
+// escaping function for SQL in the standard library: use parameterized queries
+// instead. The CheckArgs configuration already excludes prepared statement params.
+var sqlInjectionSanitizers = append(slices.Clone(numericSanitizers),
+	// Escaping helpers for values that cannot be parameterized, such as table
+	// and column names. Matched by signature only, so gosec does not depend on lib/pq.
+	taint.Sanitizer{Package: "github.com/lib/pq", Method: "QuoteIdentifier"},
+	taint.Sanitizer{Package: "github.com/lib/pq", Method: "QuoteLiteral"},
+)
+
 // SQLInjection returns a configuration for detecting SQL injection vulnerabilities.
 func SQLInjection() taint.Config {
 	return taint.Config{
-		Sources: []taint.Source{
-			// Type sources: tainted when received as parameters
-			{Package: "net/http", Name: "Request", Pointer: true},
-			{Package: "net/url", Name: "URL", Pointer: true},
-			{Package: "net/url", Name: "Values"},
-			{Package: "bufio", Name: "Reader", Pointer: true},
-			{Package: "bufio", Name: "Scanner", Pointer: true},
-
-			// Function sources
-			{Package: "os", Name: "Args", IsFunc: true},
-			{Package: "os", Name: "Getenv", IsFunc: true},
-		},
+		Sources: slices.Clone(sqlInjectionSources),
 		Sinks: []taint.Sink{
 			// For SQL methods, Args[0] is receiver, Args[1] is query string
 			// Only check query string argument; prepared statement params are safe
@@ -54,12 +144,422 @@ func SQLInjection() taint.Config {
 			{Package: "database/sql", Receiver: "Tx", Method: "ExecContext", Pointer: true, CheckArgs: []int{2}},
 			{Package: "database/sql", Receiver: "Tx", Method: "Prepare", Pointer: true, CheckArgs: []int{1}},
 			{Package: "database/sql", Receiver: "Tx", Method: "PrepareContext", Pointer: true, CheckArgs: []int{2}},
+			// *sql.Conn only has context-aware methods: Args[1] is ctx, Args[2] is query
+			{Package: "database/sql", Receiver: "Conn", Method: "QueryContext", Pointer: true, CheckArgs: []int{2}},
+			{Package: "database/sql", Receiver: "Conn", Method: "QueryRowContext", Pointer: true, CheckArgs: []int{2}},
+			{Package: "database/sql", Receiver: "Conn", Method: "ExecContext", Pointer: true, CheckArgs: []int{2}},
+			{Package: "database/sql", Receiver: "Conn", Method: "PrepareContext", Pointer: true, CheckArgs: []int{2}},
+			// sqlx, matched by signature only so gosec does not depend on it.
+			// Get and Select take the destination before the query string.
+			{Package: "github.com/jmoiron/sqlx", Receiver: "DB", Method: "Queryx", Pointer: true, CheckArgs: []int{1}},
+			{Package: "github.com/jmoiron/sqlx", Receiver: "DB", Method: "QueryxContext", Pointer: true, CheckArgs: []int{2}},
+			{Package: "github.com/jmoiron/sqlx", Receiver: "DB", Method: "QueryRowx", Pointer: true, CheckArgs: []int{1}},
+			{Package: "github.com/jmoiron/sqlx", Receiver: "DB", Method: "QueryRowxContext", Pointer: true, CheckArgs: []int{2}},
+			{Package: "github.com/jmoiron/sqlx", Receiver: "DB", Method: "Get", Pointer: true, CheckArgs: []int{2}},
+			{Package: "github.com/jmoiron/sqlx", Receiver: "DB", Method: "GetContext", Pointer: true, CheckArgs: []int{3}},
+			{Package: "github.com/jmoiron/sqlx", Receiver: "DB", Method: "Select", Pointer: true, CheckArgs: []int{2}},
+			{Package: "github.com/jmoiron/sqlx", Receiver: "DB", Method: "SelectContext", Pointer: true, CheckArgs: []int{3}},
+			{Package: "github.com/jmoiron/sqlx", Receiver: "DB", Method: "NamedExec", Pointer: true, CheckArgs: []int{1}},
+			{Package: "github.com/jmoiron/sqlx", Receiver: "DB", Method: "NamedExecContext", Pointer: true, CheckArgs: []int{2}},
+			{Package: "github.com/jmoiron/sqlx", Receiver: "DB", Method: "NamedQuery", Pointer: true, CheckArgs: []int{1}},
+			{Package: "github.com/jmoiron/sqlx", Receiver: "DB", Method: "MustExec", Pointer: true, CheckArgs: []int{1}},
+			{Package: "github.com/jmoiron/sqlx", Receiver: "DB", Method: "MustExecContext", Pointer: true, CheckArgs: []int{2}},
+			{Package: "github.com/jmoiron/sqlx", Receiver: "DB", Method: "Preparex", Pointer: true, CheckArgs: []int{1}},
+			{Package: "github.com/jmoiron/sqlx", Receiver: "DB", Method: "PrepareNamed", Pointer: true, CheckArgs: []int{1}},
+			{Package: "github.com/jmoiron/sqlx", Receiver: "Tx", Method: "Queryx", Pointer: true, CheckArgs: []int{1}},
+			{Package: "github.com/jmoiron/sqlx", Receiver: "Tx", Method: "QueryxContext", Pointer: true, CheckArgs: []int{2}},
+			{Package: "github.com/jmoiron/sqlx", Receiver: "Tx", Method: "QueryRowx", Pointer: true, CheckArgs: []int{1}},
+			{Package: "github.com/jmoiron/sqlx", Receiver: "Tx", Method: "QueryRowxContext", Pointer: true, CheckArgs: []int{2}},
+			{Package: "github.com/jmoiron/sqlx", Receiver: "Tx", Method: "Get", Pointer: true, CheckArgs: []int{2}},
+			{Package: "github.com/jmoiron/sqlx", Receiver: "Tx", Method: "GetContext", Pointer: true, CheckArgs: []int{3}},
+			{Package: "github.com/jmoiron/sqlx", Receiver: "Tx", Method: "Select", Pointer: true, CheckArgs: []int{2}},
+			{Package: "github.com/jmoiron/sqlx", Receiver: "Tx", Method: "SelectContext", Pointer: true, CheckArgs: []int{3}},
+			{Package: "github.com/jmoiron/sqlx", Receiver: "Tx", Method: "NamedExec", Pointer: true, CheckArgs: []int{1}},
+			{Package: "github.com/jmoiron/sqlx", Receiver: "Tx", Method: "NamedExecContext", Pointer: true, CheckArgs: []int{2}},
+			{Package: "github.com/jmoiron/sqlx", Receiver: "Tx", Method: "NamedQuery", Pointer: true, CheckArgs: []int{1}},
+			{Package: "github.com/jmoiron/sqlx", Receiver: "Tx", Method: "MustExec", Pointer: true, CheckArgs: []int{1}},
+			{Package: "github.com/jmoiron/sqlx", Receiver: "Tx", Method: "MustExecContext", Pointer: true, CheckArgs: []int{2}},
+			{Package: "github.com/jmoiron/sqlx", Receiver: "Tx", Method: "Preparex", Pointer: true, CheckArgs: []int{1}},
+			{Package: "github.com/jmoiron/sqlx", Receiver: "Tx", Method: "PrepareNamed", Pointer: true, CheckArgs: []int{1}},
+			// GORM methods taking raw SQL fragments. Only the SQL argument is checked,
+			// so values bound to "?" placeholders stay clean.
+			{Package: "gorm.io/gorm", Receiver: "DB", Method: "Raw", Pointer: true, CheckArgs: []int{1}},
+			{Package: "gorm.io/gorm", Receiver: "DB", Method: "Exec", Pointer: true, CheckArgs: []int{1}},
+			{Package: "gorm.io/gorm", Receiver: "DB", Method: "Where", Pointer: true, CheckArgs: []int{1}},
+			{Package: "gorm.io/gorm", Receiver: "DB", Method: "Or", Pointer: true, CheckArgs: []int{1}},
+			{Package: "gorm.io/gorm", Receiver: "DB", Method: "Not", Pointer: true, CheckArgs: []int{1}},
+			{Package: "gorm.io/gorm", Receiver: "DB", Method: "Having", Pointer: true, CheckArgs: []int{1}},
+			{Package: "gorm.io/gorm", Receiver: "DB", Method: "Order", Pointer: true, CheckArgs: []int{1}},
+			{Package: "gorm.io/gorm", Receiver: "DB", Method: "Group", Pointer: true, CheckArgs: []int{1}},
+			{Package: "gorm.io/gorm", Receiver: "DB", Method: "Joins", Pointer: true, CheckArgs: []int{1}},
 		},
-		Sanitizers: []taint.Sanitizer{
-			// No stdlib sanitizers for SQL — use parameterized queries instead.
-			// The CheckArgs configuration already excludes prepared statement params.
-		},
+		Sanitizers: slices.Clone(sqlInjectionSanitizers),
+		// gRPC request messages, e.g. req.GetName() in a service method
+		ProtoGetters: true,
+		Autofix:      sqlAutofix,
+		Classify:     sqlClassify,
+	}
+}
+
+// postgresDrivers are the import path prefixes of drivers that use numbered
+// "$1" placeholders instead of "?".
+var postgresDrivers = []string{"github.com/lib/pq", "github.com/jackc/pgx"}
+
+// sqlAutofix suggests a parameterized rewrite of a query that concatenates
+// values into quoted literals or comparisons directly at the sink, such as
+// db.Query("SELECT * FROM users WHERE name = '" + name + "'"). Other queries
+// get no suggestion, since rewriting them could change their meaning.
+func sqlAutofix(pass *analysis.Pass, call *ast.CallExpr) string {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || len(call.Args) == 0 || call.Ellipsis.IsValid() {
+		return ""
+	}
+	// Prepared statements get their values later and named queries bind fields
+	method := sel.Sel.Name
+	if strings.HasPrefix(method, "Prepare") || strings.HasPrefix(method, "Named") {
+		return ""
+	}
+
+	// The query must be the last argument, so that there are no values yet
+	// and the new ones can be appended
+	last := len(call.Args) - 1
+	query, values, ok := parameterizeQuery(call.Args[last], sqlPlaceholder(pass, sel))
+	if !ok {
+		return ""
+	}
+
+	args := make([]string, 0, len(call.Args)+len(values))
+	for _, arg := range call.Args[:last] {
+		args = append(args, types.ExprString(arg))
+	}
+	args = append(args, strconv.Quote(query))
+	for _, value := range values {
+		args = append(args, types.ExprString(value))
+	}
+	return fmt.Sprintf("Use a parameterized query: %s(%s)", types.ExprString(call.Fun), strings.Join(args, ", "))
+}
+
+// sqlPlaceholder returns the function formatting the placeholder of the n-th
+// value for the database behind the sink call.
+func sqlPlaceholder(pass *analysis.Pass, sel *ast.SelectorExpr) func(n int) string {
+	question := func(int) string { return "?" }
+	// GORM rewrites "?" for the dialect in use
+	if selection := pass.TypesInfo.Selections[sel]; selection != nil && selection.Obj().Pkg() != nil &&
+		selection.Obj().Pkg().Path() == "gorm.io/gorm" {
+		return question
+	}
+	for _, imp := range pass.Pkg.Imports() {
+		for _, driver := range postgresDrivers {
+			if strings.HasPrefix(imp.Path(), driver) {
+				return func(n int) string { return "$" + strconv.Itoa(n) }
+			}
+		}
+	}
+	return question
+}
+
+// parameterizeQuery rewrites a concatenation of string literals and values
+// into a query with placeholders. Each value must either be enclosed in
+// single quotes, which are dropped, or follow a comparison operator.
+// Returns false for any other shape, such as a value used as a table name.
+func parameterizeQuery(expr ast.Expr, placeholder func(n int) string) (string, []ast.Expr, bool) {
+	parts := concatParts(expr)
+	if len(parts) < 2 {
+		return "", nil, false
+	}
+
+	var query string
+	var values []ast.Expr
+	quoted, afterValue := false, false
+	for _, part := range parts {
+		lit, isLit := part.(*ast.BasicLit)
+		if !isLit {
+			if afterValue {
+				return "", nil, false
+			}
+			switch trimmed := strings.TrimRight(query, " "); {
+			case strings.HasSuffix(query, "'") && strings.Count(query, "'")%2 == 1:
+				query = strings.TrimSuffix(query, "'")
+				quoted = true
+			case strings.Count(query, "'")%2 == 0 && trimmed != "" && strings.ContainsRune("=<>", rune(trimmed[len(trimmed)-1])):
+				quoted = false
+			default:
+				return "", nil, false
+			}
+			values = append(values, part)
+			query += placeholder(len(values))
+			afterValue = true
+			continue
+		}
+
+		text, err := strconv.Unquote(lit.Value)
+		if lit.Kind != token.STRING || err != nil || strings.ContainsAny(text, "?$") {
+			return "", nil, false
+		}
+		if afterValue {
+			if quoted {
+				if !strings.HasPrefix(text, "'") {
+					return "", nil, false
+				}
+				text = text[1:]
+			} else if text != "" && !strings.ContainsAny(text[:1], " ),;") {
+				return "", nil, false
+			}
+		}
+		query += text
+		afterValue = false
+	}
+	if len(values) == 0 || (afterValue && quoted) {
+		return "", nil, false
+	}
+	return query, values, true
+}
+
+// concatParts returns the operands of a string concatenation in order, or
+// nil when expr is not a concatenation.
+func concatParts(expr ast.Expr) []ast.Expr {
+	bin, ok := ast.Unparen(expr).(*ast.BinaryExpr)
+	if !ok || bin.Op != token.ADD {
+		return nil
+	}
+	var parts []ast.Expr
+	for _, operand := range []ast.Expr{bin.X, bin.Y} {
+		if inner := concatParts(operand); inner != nil {
+			parts = append(parts, inner...)
+		} else {
+			parts = append(parts, ast.Unparen(operand))
+		}
+	}
+	return parts
+}
+
+// sqlClauseSubcategory tags G701 findings where the value is concatenated
+// into a part of the query that placeholders cannot stand for.
+const sqlClauseSubcategory = "sql-clause"
+
+// sqlClauses are the parts of a query that cannot be parameterized, matched
+// against the query text preceding a concatenated value. LIMIT and OFFSET are
+// checked first, since they may follow an ORDER BY clause.
+var sqlClauses = []struct {
+	pattern *regexp.Regexp
+	detail  string
+}{
+	{
+		regexp.MustCompile(`(?i)\b(LIMIT|OFFSET)\s+(\d+\s*,\s*)?$`),
+		"user input in a LIMIT or OFFSET clause; convert it to an integer or validate it against an allowlist",
+	},
+	{
+		regexp.MustCompile(`(?i)\bORDER\s+BY\s+[\w\s.,"` + "`" + `]*$`),
+		"user input in an ORDER BY clause cannot be a query parameter; validate it against an allowlist of columns and directions",
+	},
+	{
+		regexp.MustCompile(`(?i)\bGROUP\s+BY\s+[\w\s.,"` + "`" + `]*$`),
+		"user input in a GROUP BY clause cannot be a query parameter; validate it against an allowlist of columns",
+	},
+	{
+		regexp.MustCompile(`(?i)\bSELECT\s+(DISTINCT\s+)?([\w."` + "`" + `]+\s*,\s*)*$`),
+		"user input used as a column name cannot be a query parameter; validate it against an allowlist of columns",
+	},
+	{
+		regexp.MustCompile(`(?i)\b(FROM|JOIN|INTO|UPDATE|TABLE)\s+["` + "`" + `]?$`),
+		"user input used as a table name cannot be a query parameter; validate it against an allowlist of tables",
+	},
+}
+
+// sqlClassify tags a finding whose query concatenates a value into an ORDER
+// BY, GROUP BY, LIMIT or OFFSET clause, or uses it as a table or column name,
+// where it must be validated against an allowlist rather than passed as a
+// parameter. Only the query arguments of the sink are checked. The query is
+// the concatenation or fmt.Sprintf call at the sink, or the one the variable
+// passed to it is built from.
+func sqlClassify(pass *analysis.Pass, call *ast.CallExpr, sink taint.Sink) (string, string) {
+	for _, arg := range queryArgs(pass, call, sink) {
+		for _, prefix := range queryPrefixes(pass, queryParts(pass, arg)) {
+			for _, clause := range sqlClauses {
+				if clause.pattern.MatchString(prefix) {
+					return sqlClauseSubcategory, clause.detail
+				}
+			}
+		}
+	}
+	return "", ""
+}
+
+// queryArgs returns the arguments of call at the sink's CheckArgs positions,
+// which count the receiver of a method called on a value as argument 0, or
+// every argument when the sink checks all of them.
+func queryArgs(pass *analysis.Pass, call *ast.CallExpr, sink taint.Sink) []ast.Expr {
+	if len(sink.CheckArgs) == 0 {
+		return call.Args
+	}
+	offset := 0
+	if sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr); ok {
+		if selection := pass.TypesInfo.Selections[sel]; selection != nil && selection.Kind() == types.MethodVal {
+			offset = 1
+		}
+	}
+	var args []ast.Expr
+	for _, idx := range sink.CheckArgs {
+		if i := idx - offset; i >= 0 && i < len(call.Args) {
+			args = append(args, call.Args[i])
+		}
+	}
+	return args
+}
+
+// queryParts returns the expressions a query is built from: for a local
+// variable, the last value assigned to it before expr followed by the values
+// appended to it with += since, in the order they appear in the enclosing
+// function; expr itself otherwise.
+func queryParts(pass *analysis.Pass, expr ast.Expr) []ast.Expr {
+	ident, ok := ast.Unparen(expr).(*ast.Ident)
+	if !ok {
+		return []ast.Expr{expr}
+	}
+	obj, ok := pass.TypesInfo.Uses[ident].(*types.Var)
+	if !ok || obj.Parent() == nil || obj.Parent() == obj.Pkg().Scope() {
+		return []ast.Expr{expr}
+	}
+	body := enclosingFuncBody(pass, ident.Pos())
+	if body == nil {
+		return []ast.Expr{expr}
+	}
+
+	isVar := func(e ast.Expr) bool {
+		id, ok := ast.Unparen(e).(*ast.Ident)
+		return ok && (pass.TypesInfo.Defs[id] == obj || pass.TypesInfo.Uses[id] == obj)
+	}
+	var parts []ast.Expr
+	ast.Inspect(body, func(n ast.Node) bool {
+		if n == nil || n.Pos() >= ident.Pos() {
+			return false
+		}
+		switch stmt := n.(type) {
+		case *ast.FuncLit:
+			// Assignments in closures may run at any time
+			return false
+		case *ast.AssignStmt:
+			if len(stmt.Lhs) != len(stmt.Rhs) {
+				// v, err := f() assigns a value the query cannot be read from
+				for _, lhs := range stmt.Lhs {
+					if isVar(lhs) {
+						parts = nil
+					}
+				}
+				return true
+			}
+			for i, lhs := range stmt.Lhs {
+				if !isVar(lhs) {
+					continue
+				}
+				switch stmt.Tok {
+				case token.ADD_ASSIGN:
+					parts = append(parts, stmt.Rhs[i])
+				case token.ASSIGN, token.DEFINE:
+					parts = []ast.Expr{stmt.Rhs[i]}
+				default:
+					parts = nil
+				}
+			}
+		case *ast.ValueSpec:
+			for i, name := range stmt.Names {
+				if pass.TypesInfo.Defs[name] != obj {
+					continue
+				}
+				parts = nil
+				if len(stmt.Names) == len(stmt.Values) {
+					parts = []ast.Expr{stmt.Values[i]}
+				}
+			}
+		}
+		return true
+	})
+	if len(parts) == 0 {
+		return []ast.Expr{expr}
+	}
+	return parts
+}
+
+// enclosingFuncBody returns the body of the innermost function declaration or
+// literal containing pos.
+func enclosingFuncBody(pass *analysis.Pass, pos token.Pos) *ast.BlockStmt {
+	for _, file := range pass.Files {
+		if pos < file.Pos() || pos > file.End() {
+			continue
+		}
+		var body *ast.BlockStmt
+		ast.Inspect(file, func(n ast.Node) bool {
+			if n == nil || pos < n.Pos() || pos > n.End() {
+				return false
+			}
+			switch fn := n.(type) {
+			case *ast.FuncDecl:
+				body = fn.Body
+			case *ast.FuncLit:
+				body = fn.Body
+			}
+			return true
+		})
+		return body
+	}
+	return nil
+}
+
+// queryPrefixes returns the constant query text preceding each value
+// inserted into a query built from the given parts, each a concatenation, a
+// fmt.Sprintf call or a single value.
+func queryPrefixes(pass *analysis.Pass, parts []ast.Expr) []string {
+	constText := func(e ast.Expr) (string, bool) {
+		tv, ok := pass.TypesInfo.Types[e]
+		if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+			return "", false
+		}
+		return constant.StringVal(tv.Value), true
+	}
+
+	var prefixes []string
+	var text string
+	for _, part := range parts {
+		if format, ok := sprintfFormat(pass, part, constText); ok {
+			for i := 0; i < len(format)-1; i++ {
+				if format[i] != '%' {
+					continue
+				}
+				if format[i+1] == '%' {
+					i++
+					continue
+				}
+				prefixes = append(prefixes, text+format[:i])
+			}
+			text += format
+			continue
+		}
+		operands := concatParts(part)
+		if operands == nil {
+			operands = []ast.Expr{ast.Unparen(part)}
+		}
+		for _, operand := range operands {
+			if lit, ok := constText(operand); ok {
+				text += lit
+				continue
+			}
+			prefixes = append(prefixes, text)
+		}
+	}
+	return prefixes
+}
+
+// sprintfFormat returns the constant format of a fmt.Sprintf call.
+func sprintfFormat(pass *analysis.Pass, expr ast.Expr, constText func(ast.Expr) (string, bool)) (string, bool) {
+	call, ok := ast.Unparen(expr).(*ast.CallExpr)
+	if !ok || len(call.Args) == 0 {
+		return "", false
+	}
+	fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+	if !ok || fn.FullName() != "fmt.Sprintf" {
+		return "", false
 	}
+	return constText(call.Args[0])
 }
 
 // newSQLInjectionAnalyzer creates an analyzer for detecting SQL injection vulnerabilities
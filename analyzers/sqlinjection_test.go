@@ -0,0 +1,178 @@
+package analyzers_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/securego/gosec/v2"
+	"github.com/securego/gosec/v2/issue"
+)
+
+// sqlModule returns a module with the given body of a handler that has a
+// database handle and a request.
+func sqlModule(body string) map[string]string {
+	return map[string]string{
+		"go.mod": "module mycompany\n\ngo 1.25\n",
+		"app/app.go": `package app
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+)
+
+var _ = context.Background
+
+func Lookup(ctx context.Context, db *sql.DB, r *http.Request) {
+` + body + `}
+
+func byName(db *sql.DB, name string) {
+	rows, _ := db.Query(buildQuery(name))
+	_ = rows
+}
+
+func buildQuery(name string) string {
+	return "SELECT * FROM users WHERE name = '" + name + "'"
+}
+`,
+	}
+}
+
+var _ = Describe("SQL injection sink arguments", func() {
+	It("should check the query string but not the bound parameters", func() {
+		issues, err := analyzeModule("G701", gosec.NewConfig(), sqlModule(`	name := r.FormValue("name")
+	rows, _ := db.QueryContext(ctx, "SELECT * FROM users WHERE name = ?", name)
+	_ = rows
+	_, _ = db.ExecContext(ctx, "DELETE FROM users WHERE name = '"+name+"'", name)
+`), "app")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(issues).Should(HaveLen(1))
+		Expect(issues[0].Line).Should(Equal("15"))
+		Expect(issues[0].Code).Should(ContainSubstring("ExecContext"))
+	})
+})
+
+var _ = Describe("SQL injection autofix", func() {
+	autofix := func(body string) string {
+		issues, err := analyzeModule("G701", gosec.NewConfig(), sqlModule(body), "app")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(issues).Should(HaveLen(1))
+		return issues[0].Autofix
+	}
+
+	It("should suggest parameters for values concatenated in quotes at the sink", func() {
+		Expect(autofix(`	name := r.FormValue("name")
+	rows, _ := db.Query("SELECT * FROM users WHERE name = '" + name + "' AND active = 1")
+	_ = rows
+`)).Should(Equal(`Use a parameterized query: db.Query("SELECT * FROM users WHERE name = ? AND active = 1", name)`))
+	})
+
+	It("should suggest parameters for compared values and keep the leading arguments", func() {
+		Expect(autofix(`	rows, _ := db.QueryContext(ctx, "SELECT * FROM users WHERE id >= " + r.FormValue("id") + " AND name = '" + r.FormValue("name") + "'")
+	_ = rows
+`)).Should(Equal(`Use a parameterized query: db.QueryContext(ctx, "SELECT * FROM users WHERE id >= ? AND name = ?", r.FormValue("id"), r.FormValue("name"))`))
+	})
+
+	It("should not suggest a fix for a query built in another function", func() {
+		Expect(autofix(`	byName(db, r.FormValue("name"))
+`)).Should(BeEmpty())
+	})
+
+	It("should not suggest a fix for a query built before the sink", func() {
+		Expect(autofix(`	query := "SELECT * FROM users WHERE name = '" + r.FormValue("name") + "'"
+	rows, _ := db.Query(query)
+	_ = rows
+`)).Should(BeEmpty())
+	})
+
+	It("should not suggest a fix for values that cannot be parameters", func() {
+		Expect(autofix(`	rows, _ := db.Query("SELECT * FROM " + r.FormValue("table") + " WHERE id = 1")
+	_ = rows
+`)).Should(BeEmpty())
+		Expect(autofix(`	rows, _ := db.Query("SELECT * FROM users WHERE name LIKE '%" + r.FormValue("name") + "%'")
+	_ = rows
+`)).Should(BeEmpty())
+	})
+})
+
+var _ = Describe("SQL injection subcategories", func() {
+	classify := func(body string) *issue.Issue {
+		issues, err := analyzeModule("G701", gosec.NewConfig(), sqlModule(body), "app")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(issues).Should(HaveLen(1))
+		return issues[0]
+	}
+
+	DescribeTable("should recommend an allowlist for clauses that cannot be parameters",
+		func(body, detail string) {
+			found := classify(body)
+			Expect(found.Subcategory).Should(Equal("sql-clause"))
+			Expect(found.What).Should(HavePrefix("SQL injection via taint analysis: "))
+			Expect(found.What).Should(ContainSubstring(detail))
+			Expect(found.What).Should(ContainSubstring("allowlist"))
+			Expect(found.Autofix).Should(BeEmpty())
+		},
+		Entry("ORDER BY", `	rows, _ := db.Query("SELECT * FROM users ORDER BY " + r.FormValue("sort"))
+	_ = rows
+`, "ORDER BY clause"),
+		Entry("ORDER BY direction", `	rows, _ := db.Query("SELECT * FROM users ORDER BY name " + r.FormValue("dir") + " LIMIT 10")
+	_ = rows
+`, "ORDER BY clause"),
+		Entry("GROUP BY", `	rows, _ := db.Query("SELECT count(*) FROM users GROUP BY " + r.FormValue("by"))
+	_ = rows
+`, "GROUP BY clause"),
+		Entry("LIMIT", `	rows, _ := db.Query("SELECT * FROM users ORDER BY name LIMIT " + r.FormValue("limit"))
+	_ = rows
+`, "LIMIT or OFFSET clause"),
+		Entry("table name", `	rows, _ := db.Query("SELECT * FROM " + r.FormValue("table") + " WHERE id = 1")
+	_ = rows
+`, "table name"),
+		Entry("column name", `	rows, _ := db.Query("SELECT id, " + r.FormValue("column") + " FROM users")
+	_ = rows
+`, "column name"),
+		Entry("query built before the sink", `	query := "SELECT * FROM users ORDER BY " + r.FormValue("sort")
+	rows, _ := db.Query(query)
+	_ = rows
+`, "ORDER BY clause"),
+		Entry("query built with +=", `	query := "SELECT * FROM users"
+	query += " ORDER BY " + r.FormValue("sort")
+	rows, _ := db.Query(query)
+	_ = rows
+`, "ORDER BY clause"),
+		Entry("query reassigned before the sink", `	query := "SELECT * FROM users WHERE name = 'a'"
+	query = "SELECT * FROM users GROUP BY " + r.FormValue("by")
+	rows, _ := db.Query(query)
+	_ = rows
+`, "GROUP BY clause"),
+	)
+
+	It("should recognize clauses in queries formatted with fmt.Sprintf", func() {
+		module := sqlModule(`	rows, _ := db.Query(fmt.Sprintf("SELECT * FROM users ORDER BY %s", r.FormValue("sort")))
+	_ = rows
+`)
+		module["app/app.go"] = strings.Replace(module["app/app.go"], `"database/sql"`, "\"database/sql\"\n\t\"fmt\"", 1)
+		issues, err := analyzeModule("G701", gosec.NewConfig(), module, "app")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(issues).Should(HaveLen(1))
+		Expect(issues[0].Subcategory).Should(Equal("sql-clause"))
+		Expect(issues[0].What).Should(ContainSubstring("ORDER BY clause"))
+	})
+
+	It("should keep the standard description for values", func() {
+		found := classify(`	rows, _ := db.Query("SELECT * FROM users WHERE name = '" + r.FormValue("name") + "'")
+	_ = rows
+`)
+		Expect(found.Subcategory).Should(BeEmpty())
+		Expect(found.What).Should(Equal("SQL injection via taint analysis"))
+	})
+
+	It("should only classify the query argument of the sink", func() {
+		found := classify(`	rows, _ := db.Query("SELECT * FROM users WHERE name = '"+r.FormValue("name")+"'", "ORDER BY "+r.FormValue("sort"))
+	_ = rows
+`)
+		Expect(found.Subcategory).Should(BeEmpty())
+		Expect(found.What).Should(Equal("SQL injection via taint analysis"))
+	})
+})
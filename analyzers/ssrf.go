@@ -67,6 +67,15 @@ func SSRF() taint.Config {
 			// However, url.Parse itself is not a sanitizer — it doesn't restrict
 			// which hosts can be accessed.
 		},
+		// A parsed URL whose host is checked against a fixed allowlist can only
+		// reach the allowed hosts:
+		//	u, err := url.Parse(raw)
+		//	if err != nil || !allowedHosts[u.Hostname()] { return }
+		//	http.Get(u.String())
+		AllowlistKeys: []taint.AllowlistKey{
+			{Package: "net/url", Type: "URL", Field: "Host"},
+			{Package: "net/url", Type: "URL", Field: "Hostname"},
+		},
 	}
 }
 
@@ -15,6 +15,8 @@
 package analyzers
 
 import (
+	"slices"
+
 	"golang.org/x/tools/go/analysis"
 
 	"github.com/securego/gosec/v2/taint"
@@ -28,68 +30,24 @@ import (
 // input flows into Template.Parse, an attacker can invoke arbitrary methods,
 // read files, or achieve remote code execution depending on available gadgets.
 //
-// Even when the template string is static, rendering user data through
-// text/template into an HTTP response produces unescaped HTML, enabling XSS.
+// Only the template text is a sink: data passed to Execute is bound to the
+// template and never interpreted as template code. Rendering such data into
+// an HTTP response is reported by the XSS rule (G705) instead.
 func SSTI() taint.Config {
 	return taint.Config{
-		Sources: []taint.Source{
-			// Type sources: tainted when received as parameters
-			{Package: "net/http", Name: "Request", Pointer: true},
-			{Package: "net/url", Name: "Values"},
-
-			// Function sources
-			{Package: "os", Name: "Args", IsFunc: true},
-			{Package: "os", Name: "Getenv", IsFunc: true},
-
-			// I/O sources
-			{Package: "bufio", Name: "Reader", Pointer: true},
-			{Package: "bufio", Name: "Scanner", Pointer: true},
-		},
+		Sources: slices.Clone(sqlInjectionSources),
 		Sinks: []taint.Sink{
 			// CRITICAL: user input flows into the template string itself.
-			// Template.Parse takes a single string argument (the template text).
+			// Template.Parse takes a single string argument (the template text),
+			// including when chained as template.New(name).Parse(text).
 			{Package: "text/template", Receiver: "Template", Method: "Parse", Pointer: true, CheckArgs: []int{1}},
 
 			// text/template.Must wraps Parse; arg[0] is the (*Template, error) pair
 			// but in practice the taint flows through the Parse call above.
-
-			// HIGH: text/template.Execute writes unescaped output to an HTTP response.
-			// Guard: only flag when the writer (arg 1) implements net/http.ResponseWriter.
-			{
-				Package:       "text/template",
-				Receiver:      "Template",
-				Method:        "Execute",
-				Pointer:       true,
-				CheckArgs:     []int{2},
-				ArgTypeGuards: map[int]string{1: "net/http.ResponseWriter"},
-			},
-			{
-				Package:       "text/template",
-				Receiver:      "Template",
-				Method:        "ExecuteTemplate",
-				Pointer:       true,
-				CheckArgs:     []int{3},
-				ArgTypeGuards: map[int]string{1: "net/http.ResponseWriter"},
-			},
-		},
-		Sanitizers: []taint.Sanitizer{
-			// HTML escaping neutralizes both SSTI template directives and XSS payloads
-			{Package: "html", Method: "EscapeString"},
-			{Package: "html/template", Method: "HTMLEscapeString"},
-			{Package: "html/template", Method: "JSEscapeString"},
-			{Package: "net/url", Method: "QueryEscape"},
-			{Package: "net/url", Method: "PathEscape"},
-
-			// Numeric conversions produce safe output
-			{Package: "strconv", Method: "Atoi"},
-			{Package: "strconv", Method: "Itoa"},
-			{Package: "strconv", Method: "ParseInt"},
-			{Package: "strconv", Method: "ParseUint"},
-			{Package: "strconv", Method: "ParseFloat"},
-			{Package: "strconv", Method: "FormatInt"},
-			{Package: "strconv", Method: "FormatUint"},
-			{Package: "strconv", Method: "FormatFloat"},
 		},
+		// HTML escaping does not touch template delimiters, so only the numeric
+		// conversions shared with G701 clear taint from template text.
+		Sanitizers: slices.Clone(numericSanitizers),
 	}
 }
 
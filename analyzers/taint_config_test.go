@@ -0,0 +1,644 @@
+package analyzers_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/securego/gosec/v2"
+	"github.com/securego/gosec/v2/analyzers"
+	"github.com/securego/gosec/v2/issue"
+	"github.com/securego/gosec/v2/testutils"
+)
+
+// webModule is a small module with a framework-like accessor in one package
+// and a handler using it in another, so that configured signatures can refer
+// to a real import path.
+var webModule = map[string]string{
+	"go.mod": "module mycompany\n\ngo 1.25\n",
+	"web/web.go": `package web
+
+type Ctx struct {
+	params map[string]string
+}
+
+func (c *Ctx) Param(name string) string {
+	return c.params[name]
+}
+`,
+	"app/app.go": `package app
+
+import (
+	"database/sql"
+
+	"mycompany/web"
+)
+
+func Handle(db *sql.DB, c *web.Ctx) {
+	rows, err := db.Query("SELECT * FROM users WHERE id = '" + c.Param("id") + "'")
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+}
+`,
+}
+
+// dbutilModule has an in-house escaping helper that is not a built-in sanitizer.
+var dbutilModule = map[string]string{
+	"go.mod": "module mycompany\n\ngo 1.25\n",
+	"dbutil/dbutil.go": `package dbutil
+
+import "strings"
+
+func SafeIdent(name string) string {
+	return "\"" + strings.ReplaceAll(name, "\"", "\"\"") + "\""
+}
+`,
+	"app/app.go": `package app
+
+import (
+	"database/sql"
+	"net/http"
+
+	"mycompany/dbutil"
+)
+
+func Handle(db *sql.DB, r *http.Request) {
+	rows, err := db.Query("SELECT * FROM " + dbutil.SafeIdent(r.FormValue("table")))
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+}
+`,
+}
+
+// schemaModule maps a request value to a table name from a fixed registry.
+var schemaModule = map[string]string{
+	"go.mod": "module mycompany\n\ngo 1.25\n",
+	"schema/schema.go": `package schema
+
+var tables = map[string]string{"1": "users", "2": "orders", "3": "invoices"}
+
+func TableName(id string) string {
+	if name, ok := tables[id]; ok {
+		return name
+	}
+	return "users"
+}
+`,
+	"app/app.go": `package app
+
+import (
+	"database/sql"
+	"net/http"
+
+	"mycompany/schema"
+)
+
+func Handle(db *sql.DB, r *http.Request) {
+	rows, err := db.Query("SELECT * FROM " + schema.TableName(r.FormValue("id")))
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+}
+`,
+}
+
+// storeModule wraps database access behind a helper taking the query string.
+var storeModule = map[string]string{
+	"go.mod": "module mycompany\n\ngo 1.25\n",
+	"store/store.go": `package store
+
+import "database/sql"
+
+var db *sql.DB
+
+func Run(query string, args ...interface{}) error {
+	_, err := db.Exec(query, args...)
+	return err
+}
+`,
+	"app/app.go": `package app
+
+import (
+	"net/http"
+
+	"mycompany/store"
+)
+
+func Delete(r *http.Request) {
+	_ = store.Run("DELETE FROM users WHERE id = " + r.FormValue("id"))
+}
+
+func Rename(r *http.Request) {
+	_ = store.Run("UPDATE users SET name = ? WHERE id = ?", r.FormValue("name"), r.FormValue("id"))
+}
+`,
+}
+
+// logutilModule strips line breaks with an in-house helper before logging.
+var logutilModule = map[string]string{
+	"go.mod": "module mycompany\n\ngo 1.25\n",
+	"logutil/logutil.go": `package logutil
+
+import "strings"
+
+var crlf = strings.NewReplacer("\r", "", "\n", "")
+
+func StripCRLF(s string) string {
+	return crlf.Replace(s)
+}
+`,
+	"app/app.go": `package app
+
+import (
+	"log"
+	"net/http"
+
+	"mycompany/logutil"
+)
+
+func Login(r *http.Request) {
+	log.Printf("login user=%s", logutil.StripCRLF(r.FormValue("user")))
+}
+`,
+}
+
+// reportsModule passes a form value and a constant down the same chain of
+// helpers to a query, so that only the first flow is tainted.
+var reportsModule = map[string]string{
+	"go.mod": "module mycompany\n\ngo 1.25\n",
+	"app/app.go": `package app
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+func Search(db *sql.DB, r *http.Request) {
+	run(db, r.FormValue("name"))
+}
+
+func Daily(db *sql.DB) {
+	render(db, "daily")
+}
+
+func render(db *sql.DB, name string) {
+	load(db, name)
+}
+
+func load(db *sql.DB, name string) {
+	rows, _ := db.Query("SELECT * FROM reports WHERE name = '" + name + "'")
+	_ = rows
+}
+
+func run(db *sql.DB, name string) {
+	fetch(db, name)
+}
+
+func fetch(db *sql.DB, name string) {
+	rows, _ := db.Query("SELECT * FROM searches WHERE name = '" + name + "'")
+	_ = rows
+}
+`,
+}
+
+// generatedModule has a query built from a form value in a generated file,
+// and another in a handwritten file reading the value through a generated
+// helper.
+var generatedModule = map[string]string{
+	"go.mod": "module mycompany\n\ngo 1.25\n",
+	"app/user.pb.go": `package app
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+func UserName(r *http.Request) string {
+	return r.FormValue("name")
+}
+
+func DeleteUser(db *sql.DB, r *http.Request) {
+	_, _ = db.Exec("DELETE FROM users WHERE id = '" + r.FormValue("id") + "'")
+}
+`,
+	"app/app.go": `package app
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+func GetUser(db *sql.DB, r *http.Request) {
+	rows, err := db.Query("SELECT * FROM users WHERE name = '" + UserName(r) + "'")
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+}
+`,
+}
+
+// analyzeModule writes files into a temporary module and runs a single
+// analyzer with the given configuration on the package in pkgDir.
+func analyzeModule(analyzerID string, config gosec.Config, files map[string]string, pkgDir string) ([]*issue.Issue, error) {
+	issues, _, err := analyzeModuleLog(analyzerID, config, files, pkgDir)
+	return issues, err
+}
+
+// analyzeModuleLog is analyzeModule returning the gosec log too.
+func analyzeModuleLog(analyzerID string, config gosec.Config, files map[string]string, pkgDir string) ([]*issue.Issue, string, error) {
+	root := GinkgoT().TempDir()
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+			return nil, "", err
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			return nil, "", err
+		}
+	}
+
+	logger, output := testutils.NewLogger()
+	analyzer := gosec.NewAnalyzer(config, false, false, false, 1, logger)
+	analyzer.LoadAnalyzers(analyzers.Generate(false, analyzers.NewAnalyzerFilter(false, analyzerID)).AnalyzersInfo())
+	if err := analyzer.Process(nil, filepath.Join(root, pkgDir)); err != nil {
+		return nil, output.String(), err
+	}
+	issues, _, _ := analyzer.Report()
+	return issues, output.String(), nil
+}
+
+// largeModule has a handler with thousands of tainted queries, standing for
+// a huge generated function, and a small one with a single tainted query.
+func largeModule() map[string]string {
+	var body strings.Builder
+	for i := range 3000 {
+		fmt.Fprintf(&body, "\tdb.Query(\"SELECT * FROM t%d WHERE name = '\" + r.FormValue(\"name\") + \"'\")\n", i)
+	}
+	return map[string]string{
+		"go.mod": "module mycompany\n\ngo 1.25\n",
+		"app/app.go": `package app
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+func Lookup(db *sql.DB, r *http.Request) {
+	db.Query("SELECT * FROM users WHERE name = '" + r.FormValue("name") + "'")
+}
+
+func Generated(db *sql.DB, r *http.Request) {
+` + body.String() + `}
+`,
+	}
+}
+
+// dedupeModule has a form value used in two queries.
+var dedupeModule = map[string]string{
+	"go.mod": "module mycompany\n\ngo 1.25\n",
+	"app/app.go": `package app
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+func Rename(db *sql.DB, r *http.Request) {
+	name := r.FormValue("name")
+	_, _ = db.Exec("UPDATE users SET name = '" + name + "'")
+	_, _ = db.Exec("INSERT INTO audit (entry) VALUES ('rename to " + name + "')")
+}
+`,
+}
+
+// fixtureModule builds queries from fixture files in a test, and leaks a
+// context in the same test.
+var fixtureModule = map[string]string{
+	"go.mod": "module mycompany\n\ngo 1.25\n",
+	"app/app.go": `package app
+
+import "database/sql"
+
+func Open() (*sql.DB, error) {
+	return sql.Open("sqlite", ":memory:")
+}
+`,
+	"app/app_test.go": `package app
+
+import (
+	"context"
+	"os"
+	"testing"
+)
+
+func TestLookup(t *testing.T) {
+	db, _ := Open()
+	name, _ := os.ReadFile("testdata/name.txt")
+	rows, _ := db.Query("SELECT * FROM users WHERE name = '" + string(name) + "'")
+	_ = rows
+
+	ctx, _ := context.WithCancel(context.Background())
+	_ = ctx
+}
+`,
+}
+
+// messageModule has a protobuf-like request message without the generated
+// ProtoMessage method in the package at dir, and a service method building a
+// query from one of its getters.
+func messageModule(dir string) map[string]string {
+	return map[string]string{
+		"go.mod": "module mycompany\n\ngo 1.25\n",
+		dir + "/user.go": `package ` + filepath.Base(dir) + `
+
+type GetUserRequest struct {
+	Name string
+}
+
+func (x *GetUserRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+`,
+		"app/app.go": `package app
+
+import (
+	"database/sql"
+
+	api "mycompany/` + dir + `"
+)
+
+func GetUser(db *sql.DB, req *api.GetUserRequest) {
+	rows, err := db.Query("SELECT * FROM users WHERE name = '" + req.GetName() + "'")
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+}
+`,
+	}
+}
+
+var _ = Describe("taint rule configuration", func() {
+	Context("sources", func() {
+		It("should not treat unknown accessors as sources by default", func() {
+			issues, err := analyzeModule("G701", gosec.NewConfig(), webModule, "app")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(issues).Should(BeEmpty())
+		})
+
+		DescribeTable("should treat configured accessors as sources",
+			func(signature string) {
+				config := gosec.NewConfig()
+				config.Set("G701", map[string]interface{}{
+					"sources": []interface{}{signature},
+				})
+				issues, err := analyzeModule("G701", config, webModule, "app")
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(issues).Should(HaveLen(1))
+				Expect(issues[0].RuleID).Should(Equal("G701"))
+			},
+			Entry("package-qualified pointer receiver", "mycompany/web.(*Ctx).Param"),
+			Entry("go/types notation", "(*mycompany/web.Ctx).Param"),
+			Entry("receiver without pointer marker", "mycompany/web.Ctx.Param"),
+		)
+
+		It("should ignore sources configured for another rule", func() {
+			config := gosec.NewConfig()
+			config.Set("G702", map[string]interface{}{
+				"sources": []interface{}{"mycompany/web.(*Ctx).Param"},
+			})
+			issues, err := analyzeModule("G701", config, webModule, "app")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(issues).Should(BeEmpty())
+		})
+	})
+
+	Context("protobuf messages", func() {
+		It("should treat getters of messages in packages named like generated code as sources", func() {
+			issues, err := analyzeModule("G701", gosec.NewConfig(), messageModule("gen/userpb"), "app")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(issues).Should(HaveLen(1))
+		})
+
+		It("should not treat getters of other types as sources by default", func() {
+			issues, err := analyzeModule("G701", gosec.NewConfig(), messageModule("api/users"), "app")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(issues).Should(BeEmpty())
+		})
+
+		It("should treat getters of messages in configured packages as sources", func() {
+			config := gosec.NewConfig()
+			config.Set("G701", map[string]interface{}{
+				"proto_packages": []interface{}{"mycompany/api"},
+			})
+			issues, err := analyzeModule("G701", config, messageModule("api/users"), "app")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(issues).Should(HaveLen(1))
+		})
+	})
+
+	Context("excluded paths", func() {
+		It("should report sinks in every file by default", func() {
+			issues, err := analyzeModule("G701", gosec.NewConfig(), generatedModule, "app")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(issues).Should(HaveLen(2))
+		})
+
+		It("should not report sinks in excluded files", func() {
+			config := gosec.NewConfig()
+			config.Set("G701", map[string]interface{}{
+				"exclude_paths": []interface{}{"*.pb.go"},
+			})
+			issues, err := analyzeModule("G701", config, generatedModule, "app")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(issues).Should(HaveLen(1))
+			Expect(filepath.Base(issues[0].File)).Should(Equal("app.go"))
+		})
+
+		It("should not exclude the files of other rules", func() {
+			config := gosec.NewConfig()
+			config.Set("G702", map[string]interface{}{
+				"exclude_paths": []interface{}{"*.pb.go"},
+			})
+			issues, err := analyzeModule("G701", config, generatedModule, "app")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(issues).Should(HaveLen(2))
+		})
+	})
+
+	Context("sanitizers", func() {
+		It("should report values passed through unknown helpers by default", func() {
+			issues, err := analyzeModule("G701", gosec.NewConfig(), dbutilModule, "app")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(issues).Should(HaveLen(1))
+		})
+
+		It("should clear taint passed through configured sanitizers", func() {
+			config := gosec.NewConfig()
+			config.Set("G701", map[string]interface{}{
+				"sanitizers": []interface{}{"mycompany/dbutil.SafeIdent"},
+			})
+			issues, err := analyzeModule("G701", config, dbutilModule, "app")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(issues).Should(BeEmpty())
+		})
+
+		It("should trust the results of configured registry functions", func() {
+			issues, err := analyzeModule("G701", gosec.NewConfig(), schemaModule, "app")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(issues).Should(HaveLen(1))
+
+			config := gosec.NewConfig()
+			config.Set("G701", map[string]interface{}{
+				"sanitizers": []interface{}{"mycompany/schema.TableName"},
+			})
+			issues, err = analyzeModule("G701", config, schemaModule, "app")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(issues).Should(BeEmpty())
+		})
+
+		It("should clear log injection taint passed through configured sanitizers", func() {
+			issues, err := analyzeModule("G706", gosec.NewConfig(), logutilModule, "app")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(issues).Should(HaveLen(1))
+
+			config := gosec.NewConfig()
+			config.Set("G706", map[string]interface{}{
+				"sanitizers": []interface{}{"mycompany/logutil.StripCRLF"},
+			})
+			issues, err = analyzeModule("G706", config, logutilModule, "app")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(issues).Should(BeEmpty())
+		})
+	})
+
+	Context("sinks", func() {
+		It("should not know about wrapper functions by default", func() {
+			issues, err := analyzeModule("G701", gosec.NewConfig(), storeModule, "app")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(issues).Should(BeEmpty())
+		})
+
+		It("should check the configured argument of configured sinks", func() {
+			config := gosec.NewConfig()
+			config.Set("G701", map[string]interface{}{
+				"sinks": []interface{}{
+					map[string]interface{}{"signature": "mycompany/store.Run", "args": []interface{}{float64(0)}},
+				},
+			})
+			issues, err := analyzeModule("G701", config, storeModule, "app")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(issues).Should(HaveLen(1))
+			Expect(issues[0].Line).Should(Equal("10"))
+		})
+	})
+
+	Context("test files", func() {
+		It("should not analyze test files by default", func() {
+			issues, err := analyzeModule("G701", gosec.NewConfig(), fixtureModule, "app")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(issues).Should(BeEmpty())
+		})
+
+		It("should analyze test files when enabled", func() {
+			config := gosec.NewConfig()
+			config.SetGlobal(gosec.TaintTests, "enabled")
+			issues, err := analyzeModule("G701", config, fixtureModule, "app")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(issues).Should(HaveLen(1))
+			Expect(issues[0].File).Should(HaveSuffix("app_test.go"))
+			Expect(issues[0].Line).Should(Equal("12"))
+		})
+
+		It("should not run the other rules on test files when enabled", func() {
+			config := gosec.NewConfig()
+			config.SetGlobal(gosec.TaintTests, "enabled")
+			issues, err := analyzeModule("G118", config, fixtureModule, "app")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(issues).Should(BeEmpty())
+		})
+	})
+
+	Context("call depth", func() {
+		It("should follow callers without a limit by default", func() {
+			issues, err := analyzeModule("G701", gosec.NewConfig(), reportsModule, "app")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(issues).Should(HaveLen(1))
+			Expect(issues[0].Line).Should(Equal("30"))
+		})
+
+		It("should assume taint where the configured depth stops the analysis", func() {
+			config := gosec.NewConfig()
+			config.Set("G701", map[string]interface{}{
+				"max_call_depth": float64(1),
+			})
+			issues, err := analyzeModule("G701", config, reportsModule, "app")
+			Expect(err).ShouldNot(HaveOccurred())
+			// The tainted chain is still reported, and so is the constant
+			// one, since its root is beyond the cap.
+			Expect(issues).Should(HaveLen(2))
+			lines := []string{issues[0].Line, issues[1].Line}
+			Expect(lines).Should(ConsistOf("21", "30"))
+		})
+	})
+
+	Context("function timeout", func() {
+		It("should analyze every function without a limit by default", func() {
+			issues, output, err := analyzeModuleLog("G701", gosec.NewConfig(), largeModule(), "app")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(issues).Should(HaveLen(3001))
+			Expect(output).ShouldNot(ContainSubstring("function_timeout"))
+		})
+
+		It("should skip a function exceeding the timeout and analyze the others", func() {
+			config := gosec.NewConfig()
+			config.Set("G701", map[string]interface{}{
+				"function_timeout": "1ns",
+			})
+			issues, output, err := analyzeModuleLog("G701", config, largeModule(), "app")
+			Expect(err).ShouldNot(HaveOccurred())
+			// The small function ends before the deadline is first checked.
+			Expect(issues).Should(HaveLen(1))
+			Expect(issues[0].Line).Should(Equal("9"))
+			Expect(output).Should(MatchRegexp(`taint analysis G701: skipped \S+\.Generated at \S+app\.go:12`))
+			Expect(output).Should(ContainSubstring("exceeded the function_timeout of 1ns"))
+		})
+	})
+
+	Context("source deduplication", func() {
+		It("should report every sink of a source by default", func() {
+			issues, err := analyzeModule("G701", gosec.NewConfig(), dedupeModule, "app")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(issues).Should(HaveLen(2))
+			for _, i := range issues {
+				Expect(i.TaintFlow.MergedSinks).Should(BeEmpty())
+			}
+		})
+
+		It("should report the earliest sink of a source when enabled", func() {
+			config := gosec.NewConfig()
+			config.Set("G701", map[string]interface{}{
+				"dedupe_sources": true,
+			})
+			issues, err := analyzeModule("G701", config, dedupeModule, "app")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(issues).Should(HaveLen(1))
+			Expect(issues[0].Line).Should(Equal("10"))
+			Expect(issues[0].TaintFlow.MergedSinks).Should(HaveLen(1))
+			Expect(issues[0].TaintFlow.MergedSinks[0].Line).Should(Equal("11"))
+		})
+	})
+})
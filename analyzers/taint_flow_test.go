@@ -0,0 +1,183 @@
+package analyzers_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/securego/gosec/v2"
+	"github.com/securego/gosec/v2/issue"
+	"github.com/securego/gosec/v2/taint"
+)
+
+// concatModule builds a query by concatenating a form value in a handler.
+var concatModule = map[string]string{
+	"go.mod": "module mycompany\n\ngo 1.25\n",
+	"app/app.go": `package app
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+func Lookup(db *sql.DB, r *http.Request) {
+	id := r.FormValue("id")
+	query := "SELECT * FROM users WHERE id = '" + id + "'"
+	rows, _ := db.Query(query)
+	_ = rows
+}
+`,
+}
+
+// mapModule stores a form value in a map and queries with another entry of
+// it, which is only tainted because the map is tracked as a whole.
+var mapModule = map[string]string{
+	"go.mod": "module mycompany\n\ngo 1.25\n",
+	"app/app.go": `package app
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+func Lookup(db *sql.DB, r *http.Request) {
+	params := map[string]string{"table": "users"}
+	params["id"] = r.FormValue("id")
+	rows, _ := db.Query("SELECT * FROM " + params["table"])
+	_ = rows
+}
+`,
+}
+
+// sliceModule stores a form value in a slice made in place and queries with
+// another element of it, which is only tainted because the slice is tracked
+// as a whole.
+var sliceModule = map[string]string{
+	"go.mod": "module mycompany\n\ngo 1.25\n",
+	"app/app.go": `package app
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+func Lookup(db *sql.DB, r *http.Request, keys []string) {
+	cols := make([]string, len(keys))
+	cols[0] = "name"
+	cols[1] = r.FormValue("col")
+	rows, _ := db.Query("SELECT " + cols[0] + " FROM users")
+	_ = rows
+}
+`,
+}
+
+var _ = Describe("taint flow", func() {
+	It("should trace a concatenated query from the request to the sink", func() {
+		issues, err := analyzeModule("G701", gosec.NewConfig(), concatModule, "app")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(issues).Should(HaveLen(1))
+
+		flow := issues[0].Flow
+		Expect(len(flow)).Should(BeNumerically(">=", 3))
+
+		source := flow[0]
+		Expect(source.File).Should(HaveSuffix("app.go"))
+		Expect(source.Line).Should(Equal("8"))
+		Expect(source.Description).Should(ContainSubstring("parameter r"))
+
+		Expect(flow[1].Line).Should(Equal("9"))
+		Expect(flow[1].Description).Should(ContainSubstring("FormValue"))
+
+		sink := flow[len(flow)-1]
+		Expect(sink.File).Should(Equal(issues[0].File))
+		Expect(sink.Line).Should(Equal(issues[0].Line))
+		Expect(sink.Col).Should(Equal(issues[0].Col))
+		Expect(sink.Description).Should(ContainSubstring("Query"))
+	})
+
+	It("should report a direct flow with high confidence", func() {
+		issues, err := analyzeModule("G701", gosec.NewConfig(), concatModule, "app")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(issues).Should(HaveLen(1))
+		Expect(issues[0].Confidence).Should(Equal(issue.High))
+	})
+
+	It("should report a flow through a map tracked as a whole with medium confidence", func() {
+		issues, err := analyzeModule("G701", gosec.NewConfig(), mapModule, "app")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(issues).Should(HaveLen(1))
+		Expect(issues[0].Confidence).Should(Equal(issue.Medium))
+	})
+
+	It("should report a flow through a slice tracked as a whole with medium confidence", func() {
+		issues, err := analyzeModule("G701", gosec.NewConfig(), sliceModule, "app")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(issues).Should(HaveLen(1))
+		Expect(issues[0].Confidence).Should(Equal(issue.Medium))
+	})
+
+	Context("with taint diagnostics", func() {
+		var config gosec.Config
+
+		BeforeEach(func() {
+			config = gosec.NewConfig()
+			config.SetGlobal(gosec.TaintDiagnostics, "true")
+		})
+
+		It("should report the map tracked as a whole", func() {
+			issues, err := analyzeModule("G701", config, mapModule, "app")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(issues).Should(HaveLen(1))
+			Expect(issues[0].Diagnostics).Should(HaveLen(1))
+			Expect(issues[0].Diagnostics[0].Line).Should(Equal("9"))
+			Expect(issues[0].Diagnostics[0].Description).Should(ContainSubstring("map tainted as a whole"))
+		})
+
+		It("should report the slice tracked as a whole", func() {
+			issues, err := analyzeModule("G701", config, sliceModule, "app")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(issues).Should(HaveLen(1))
+			Expect(issues[0].Diagnostics).Should(HaveLen(1))
+			Expect(issues[0].Diagnostics[0].Line).Should(Equal("9"))
+			Expect(issues[0].Diagnostics[0].Description).Should(ContainSubstring("slice tainted as a whole"))
+		})
+
+		It("should not report diagnostics for a direct flow", func() {
+			issues, err := analyzeModule("G701", config, concatModule, "app")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(issues).Should(HaveLen(1))
+			Expect(issues[0].Diagnostics).Should(BeEmpty())
+		})
+	})
+
+	It("should not report diagnostics unless they are enabled", func() {
+		issues, err := analyzeModule("G701", gosec.NewConfig(), mapModule, "app")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(issues).Should(HaveLen(1))
+		Expect(issues[0].Diagnostics).Should(BeEmpty())
+	})
+
+	It("should write the taint graph of analyzed functions in debug mode", func() {
+		dir := GinkgoT().TempDir()
+		config := gosec.NewConfig()
+		config.Set("G701", map[string]interface{}{
+			taint.ConfigGraphDir: dir,
+		})
+		issues, err := analyzeModule("G701", config, concatModule, "app")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(issues).Should(HaveLen(1))
+
+		// gosec loads the analyzed package by its files, so the package part
+		// of the function name is not the import path.
+		files, err := filepath.Glob(filepath.Join(dir, "*.Lookup.dot"))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(files).Should(HaveLen(1))
+		dot, err := os.ReadFile(files[0])
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(string(dot)).Should(HavePrefix("digraph "))
+		Expect(string(dot)).Should(ContainSubstring(`[label="source: parameter r : *net/http.Request", color=red]`))
+		Expect(string(dot)).Should(ContainSubstring(`[label="sink: t3 = (*database/sql.DB).Query(db, t2, nil:[]any...)", shape=box]`))
+	})
+})
@@ -0,0 +1,77 @@
+// (c) Copyright gosec's authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyzers
+
+import (
+	"cmp"
+	"slices"
+
+	"golang.org/x/tools/go/callgraph/cha"
+	"golang.org/x/tools/go/ssa"
+	"golang.org/x/tools/go/ssa/ssautil"
+
+	"github.com/securego/gosec/v2/issue"
+	"github.com/securego/gosec/v2/taint"
+)
+
+// AnalyzeProgram runs the predefined taint rules on pkgs, packages of an SSA
+// program already built by the caller, and returns the issues found. It lets
+// tools that build SSA for their own analyses run the taint rules without
+// gosec loading and building the program again. The packages must have been
+// built, and config is the gosec configuration holding the rule settings.
+//
+// The issues are not filtered by #nosec annotations, and have no autofix or
+// subcategory, as these need the syntax and type information that SSA does
+// not keep.
+func AnalyzeProgram(prog *ssa.Program, pkgs []*ssa.Package, config map[string]interface{}) ([]*issue.Issue, error) {
+	srcFuncs := sourceFuncs(prog, pkgs)
+	if len(srcFuncs) == 0 {
+		return nil, nil
+	}
+
+	// The call graph of the program is shared by all rules
+	callGraph := cha.CallGraph(prog)
+	var issues []*issue.Issue
+	for _, r := range defaultTaintRules() {
+		ruleIssues, err := taint.Run(r.rule, &r.config, config, srcFuncs, callGraph)
+		if err != nil {
+			return nil, err
+		}
+		issues = append(issues, ruleIssues...)
+	}
+	return issues, nil
+}
+
+// sourceFuncs returns the functions declared in the source of pkgs,
+// including anonymous ones, ordered by position as in buildssa.
+func sourceFuncs(prog *ssa.Program, pkgs []*ssa.Package) []*ssa.Function {
+	inPkgs := make(map[*ssa.Package]bool, len(pkgs))
+	for _, pkg := range pkgs {
+		if pkg != nil {
+			inPkgs[pkg] = true
+		}
+	}
+
+	var funcs []*ssa.Function
+	for fn := range ssautil.AllFunctions(prog) {
+		if fn.Synthetic == "" && inPkgs[fn.Pkg] {
+			funcs = append(funcs, fn)
+		}
+	}
+	slices.SortFunc(funcs, func(a, b *ssa.Function) int {
+		return cmp.Compare(a.Pos(), b.Pos())
+	})
+	return funcs
+}
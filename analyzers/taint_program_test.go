@@ -0,0 +1,74 @@
+package analyzers_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"golang.org/x/tools/go/packages"
+	"golang.org/x/tools/go/ssa"
+	"golang.org/x/tools/go/ssa/ssautil"
+
+	"github.com/securego/gosec/v2"
+	"github.com/securego/gosec/v2/analyzers"
+)
+
+// buildProgram loads the module made of files and builds the SSA program of
+// its packages, as a tool with its own SSA pipeline would.
+func buildProgram(files map[string]string) (*ssa.Program, []*ssa.Package) {
+	GinkgoHelper()
+
+	root := GinkgoT().TempDir()
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		Expect(os.MkdirAll(filepath.Dir(path), 0o750)).To(Succeed())
+		Expect(os.WriteFile(path, []byte(content), 0o600)).To(Succeed())
+	}
+
+	pkgs, err := packages.Load(&packages.Config{Mode: gosec.LoadMode, Dir: root}, "./...")
+	Expect(err).NotTo(HaveOccurred())
+	Expect(packages.PrintErrors(pkgs)).To(BeZero())
+
+	prog, ssaPkgs := ssautil.Packages(pkgs, ssa.BuilderMode(0))
+	prog.Build()
+	return prog, ssaPkgs
+}
+
+var _ = Describe("AnalyzeProgram", func() {
+	It("should run the taint rules on a program built by the caller", func() {
+		prog, pkgs := buildProgram(webModule)
+
+		issues, err := analyzers.AnalyzeProgram(prog, pkgs, gosec.NewConfig())
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(issues).Should(BeEmpty())
+
+		config := gosec.NewConfig()
+		config.Set("G701", map[string]interface{}{
+			"sources": []interface{}{"mycompany/web.(*Ctx).Param"},
+		})
+		issues, err = analyzers.AnalyzeProgram(prog, pkgs, config)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(issues).Should(HaveLen(1))
+		Expect(issues[0].RuleID).Should(Equal("G701"))
+		Expect(issues[0].File).Should(HaveSuffix(filepath.Join("app", "app.go")))
+		Expect(issues[0].Line).Should(Equal("10"))
+	})
+
+	It("should only analyze the given packages", func() {
+		prog, _ := buildProgram(webModule)
+
+		issues, err := analyzers.AnalyzeProgram(prog, nil, gosec.NewConfig())
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(issues).Should(BeEmpty())
+	})
+
+	It("should report invalid rule settings", func() {
+		prog, pkgs := buildProgram(webModule)
+
+		config := gosec.NewConfig()
+		config.Set("G701", map[string]interface{}{"max_call_depth": "deep"})
+		_, err := analyzers.AnalyzeProgram(prog, pkgs, config)
+		Expect(err).Should(HaveOccurred())
+	})
+})
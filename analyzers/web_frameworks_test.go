@@ -0,0 +1,724 @@
+package analyzers_test
+
+import (
+	"maps"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/securego/gosec/v2"
+)
+
+// frameworkStubs are minimal stand-ins for third-party modules (gin, echo,
+// gorilla/mux, chi, lib/pq, sqlx, GORM, the MongoDB driver and go-ldap), wired in through
+// replace directives so the samples build without network access.
+var frameworkStubs = map[string]string{
+	"go.mod": `module app
+
+go 1.25
+
+require (
+	github.com/gin-gonic/gin v1.0.0
+	github.com/go-chi/chi/v5 v5.0.0
+	github.com/go-ldap/ldap/v3 v3.0.0
+	github.com/gorilla/mux v1.0.0
+	github.com/labstack/echo/v4 v4.0.0
+	github.com/jmoiron/sqlx v1.0.0
+	github.com/lib/pq v1.0.0
+	go.mongodb.org/mongo-driver v1.0.0
+	gorm.io/gorm v1.0.0
+)
+
+replace (
+	github.com/gin-gonic/gin => ./stubs/gin
+	github.com/go-chi/chi/v5 => ./stubs/chi
+	github.com/go-ldap/ldap/v3 => ./stubs/ldap
+	github.com/gorilla/mux => ./stubs/mux
+	github.com/labstack/echo/v4 => ./stubs/echo
+	github.com/jmoiron/sqlx => ./stubs/sqlx
+	github.com/lib/pq => ./stubs/pq
+	go.mongodb.org/mongo-driver => ./stubs/mongo
+	gorm.io/gorm => ./stubs/gorm
+)
+`,
+	"stubs/gin/go.mod": "module github.com/gin-gonic/gin\n\ngo 1.25\n",
+	"stubs/gin/gin.go": `package gin
+
+type Context struct {
+	params map[string]string
+}
+
+func (c *Context) Query(key string) string { return c.params[key] }
+
+func (c *Context) Param(key string) string { return c.params[key] }
+
+func (c *Context) PostForm(key string) string { return c.params[key] }
+
+func (c *Context) GetQuery(key string) (string, bool) {
+	v, ok := c.params[key]
+	return v, ok
+}
+`,
+	"stubs/mux/go.mod": "module github.com/gorilla/mux\n\ngo 1.25\n",
+	"stubs/mux/mux.go": `package mux
+
+import "net/http"
+
+func Vars(r *http.Request) map[string]string { return map[string]string{} }
+`,
+	"stubs/chi/go.mod": "module github.com/go-chi/chi/v5\n\ngo 1.25\n",
+	"stubs/chi/chi.go": `package chi
+
+import "net/http"
+
+func URLParam(r *http.Request, key string) string { return "" }
+`,
+	"stubs/pq/go.mod": "module github.com/lib/pq\n\ngo 1.25\n",
+	"stubs/pq/quote.go": `package pq
+
+import "strings"
+
+func QuoteIdentifier(name string) string {
+	return "\"" + strings.ReplaceAll(name, "\"", "\"\"") + "\""
+}
+
+func QuoteLiteral(literal string) string {
+	return "'" + strings.ReplaceAll(literal, "'", "''") + "'"
+}
+`,
+	"stubs/sqlx/go.mod": "module github.com/jmoiron/sqlx\n\ngo 1.25\n",
+	"stubs/sqlx/sqlx.go": `package sqlx
+
+import "database/sql"
+
+type DB struct {
+	*sql.DB
+}
+
+func (db *DB) Get(dest interface{}, query string, args ...interface{}) error { return nil }
+
+func (db *DB) Select(dest interface{}, query string, args ...interface{}) error { return nil }
+
+func (db *DB) Queryx(query string, args ...interface{}) (*sql.Rows, error) { return nil, nil }
+
+func (db *DB) NamedExec(query string, arg interface{}) (sql.Result, error) { return nil, nil }
+
+func (db *DB) MustExec(query string, args ...interface{}) sql.Result { return nil }
+`,
+	"stubs/gorm/go.mod": "module gorm.io/gorm\n\ngo 1.25\n",
+	"stubs/gorm/gorm.go": `package gorm
+
+type DB struct {
+	Error error
+}
+
+func (db *DB) Raw(sql string, values ...interface{}) *DB { return db }
+
+func (db *DB) Exec(sql string, values ...interface{}) *DB { return db }
+
+func (db *DB) Where(query interface{}, args ...interface{}) *DB { return db }
+
+func (db *DB) Find(dest interface{}, conds ...interface{}) *DB { return db }
+
+func (db *DB) Scan(dest interface{}) *DB { return db }
+`,
+	"stubs/mongo/go.mod": "module go.mongodb.org/mongo-driver\n\ngo 1.25\n",
+	"stubs/mongo/bson/bson.go": `package bson
+
+type M map[string]interface{}
+
+type E struct {
+	Key   string
+	Value interface{}
+}
+
+type D []E
+
+type A []interface{}
+
+func UnmarshalExtJSON(data []byte, canonical bool, val interface{}) error { return nil }
+`,
+	"stubs/mongo/mongo/mongo.go": `package mongo
+
+import "context"
+
+type Cursor struct{}
+
+type SingleResult struct{}
+
+type Collection struct{}
+
+func (c *Collection) Find(ctx context.Context, filter interface{}) (*Cursor, error) { return nil, nil }
+
+func (c *Collection) FindOne(ctx context.Context, filter interface{}) *SingleResult { return nil }
+
+func (c *Collection) Aggregate(ctx context.Context, pipeline interface{}) (*Cursor, error) {
+	return nil, nil
+}
+
+func (c *Collection) Distinct(ctx context.Context, fieldName string, filter interface{}) ([]interface{}, error) {
+	return nil, nil
+}
+`,
+	"stubs/ldap/go.mod": "module github.com/go-ldap/ldap/v3\n\ngo 1.25\n",
+	"stubs/ldap/ldap.go": `package ldap
+
+import "strings"
+
+const (
+	ScopeWholeSubtree = 2
+	NeverDerefAliases = 0
+)
+
+type Control interface{}
+
+type SearchRequest struct {
+	BaseDN     string
+	Filter     string
+	Attributes []string
+}
+
+type SearchResult struct{}
+
+type Conn struct{}
+
+func (l *Conn) Search(req *SearchRequest) (*SearchResult, error) { return nil, nil }
+
+func NewSearchRequest(baseDN string, scope, derefAliases, sizeLimit, timeLimit int, typesOnly bool,
+	filter string, attributes []string, controls []Control) *SearchRequest {
+	return &SearchRequest{BaseDN: baseDN, Filter: filter, Attributes: attributes}
+}
+
+func EscapeFilter(filter string) string {
+	return strings.NewReplacer("\\", "\\5c", "*", "\\2a", "(", "\\28", ")", "\\29").Replace(filter)
+}
+`,
+	"stubs/echo/go.mod": "module github.com/labstack/echo/v4\n\ngo 1.25\n",
+	"stubs/echo/echo.go": `package echo
+
+type Context interface {
+	QueryParam(name string) string
+	FormValue(name string) string
+	Param(name string) string
+}
+`,
+}
+
+// withHandler returns the framework stubs together with a handler package.
+func withHandler(code string) map[string]string {
+	files := maps.Clone(frameworkStubs)
+	files["handler/handler.go"] = code
+	return files
+}
+
+var _ = Describe("web framework taint sources", func() {
+	DescribeTable("SQL injection through framework accessors",
+		func(code string, expected int) {
+			issues, err := analyzeModule("G701", gosec.NewConfig(), withHandler(code), "handler")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(issues).Should(HaveLen(expected))
+		},
+		Entry("gin query concatenated into a query", `package handler
+
+import (
+	"database/sql"
+
+	"github.com/gin-gonic/gin"
+)
+
+func Search(db *sql.DB, c *gin.Context) {
+	rows, err := db.Query("SELECT * FROM items WHERE name = '" + c.Query("name") + "'")
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+}
+`, 1),
+		Entry("gin path parameter concatenated into a query", `package handler
+
+import (
+	"database/sql"
+
+	"github.com/gin-gonic/gin"
+)
+
+func Delete(db *sql.DB, c *gin.Context) {
+	_, _ = db.Exec("DELETE FROM items WHERE id = " + c.Param("id"))
+}
+`, 1),
+		Entry("gin optional query parameter", `package handler
+
+import (
+	"database/sql"
+
+	"github.com/gin-gonic/gin"
+)
+
+func Filter(db *sql.DB, c *gin.Context) {
+	if v, ok := c.GetQuery("owner"); ok {
+		_, _ = db.Exec("DELETE FROM items WHERE owner = '" + v + "'")
+	}
+}
+`, 1),
+		Entry("gin form value as a query parameter", `package handler
+
+import (
+	"database/sql"
+
+	"github.com/gin-gonic/gin"
+)
+
+func Create(db *sql.DB, c *gin.Context) {
+	_, _ = db.Exec("INSERT INTO items (name) VALUES (?)", c.PostForm("name"))
+}
+`, 0),
+		Entry("echo query parameter concatenated into a query", `package handler
+
+import (
+	"database/sql"
+
+	"github.com/labstack/echo/v4"
+)
+
+func Search(db *sql.DB, c echo.Context) error {
+	rows, err := db.Query("SELECT * FROM items WHERE name = '" + c.QueryParam("name") + "'")
+	if err != nil {
+		return err
+	}
+	return rows.Close()
+}
+`, 1),
+		Entry("echo form value concatenated into a query", `package handler
+
+import (
+	"database/sql"
+
+	"github.com/labstack/echo/v4"
+)
+
+func Update(db *sql.DB, c echo.Context) error {
+	_, err := db.Exec("UPDATE items SET name = '" + c.FormValue("name") + "'")
+	return err
+}
+`, 1),
+		Entry("echo path parameter as a query parameter", `package handler
+
+import (
+	"database/sql"
+
+	"github.com/labstack/echo/v4"
+)
+
+func Get(db *sql.DB, c echo.Context) error {
+	rows, err := db.Query("SELECT * FROM items WHERE id = $1", c.Param("id"))
+	if err != nil {
+		return err
+	}
+	return rows.Close()
+}
+`, 0),
+	)
+
+	DescribeTable("SQL injection through query values and router path parameters",
+		func(code string, expected int) {
+			issues, err := analyzeModule("G701", gosec.NewConfig(), withHandler(code), "handler")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(issues).Should(HaveLen(expected))
+		},
+		Entry("chi path parameter concatenated into a query", `package handler
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func Delete(db *sql.DB, r *http.Request) {
+	_, _ = db.Exec("DELETE FROM items WHERE id = " + chi.URLParam(r, "id"))
+}
+`, 1),
+		Entry("chi path parameter as a query parameter", `package handler
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func Delete(db *sql.DB, r *http.Request) {
+	_, _ = db.Exec("DELETE FROM items WHERE id = $1", chi.URLParam(r, "id"))
+}
+`, 0),
+		Entry("gorilla path variable concatenated into a query", `package handler
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+func Get(db *sql.DB, r *http.Request) {
+	vars := mux.Vars(r)
+	rows, err := db.Query("SELECT * FROM items WHERE id = " + vars["id"])
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+}
+`, 1),
+		Entry("gorilla path variable as a query parameter", `package handler
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+func Get(db *sql.DB, r *http.Request) {
+	rows, err := db.Query("SELECT * FROM items WHERE id = $1", mux.Vars(r)["id"])
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+}
+`, 0),
+		Entry("query values indexed and read with Get", `package handler
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+func Search(db *sql.DB, r *http.Request) {
+	values := r.URL.Query()
+	_, _ = db.Exec("DELETE FROM items WHERE owner = '" + values["owner"][0] + "'")
+	_, _ = db.Exec("DELETE FROM items WHERE name = '" + values.Get("name") + "'")
+}
+`, 2),
+		Entry("query values parsed from a stored query string", `package handler
+
+import (
+	"database/sql"
+	"net/url"
+)
+
+func Replay(db *sql.DB, rawQuery string) {
+	values, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return
+	}
+	_, _ = db.Exec("DELETE FROM items WHERE id = " + values.Get("id"))
+}
+`, 1),
+	)
+
+	DescribeTable("SQL injection through lib/pq escaping helpers",
+		func(code string, expected int) {
+			issues, err := analyzeModule("G701", gosec.NewConfig(), withHandler(code), "handler")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(issues).Should(HaveLen(expected))
+		},
+		Entry("quoted identifier and literal", `package handler
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/lib/pq"
+)
+
+func List(db *sql.DB, r *http.Request) {
+	table := pq.QuoteIdentifier(r.FormValue("table"))
+	owner := pq.QuoteLiteral(r.FormValue("owner"))
+	rows, err := db.Query("SELECT * FROM " + table + " WHERE owner = " + owner)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+}
+`, 0),
+		Entry("unquoted identifier", `package handler
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/lib/pq"
+)
+
+func List(db *sql.DB, r *http.Request) {
+	owner := pq.QuoteLiteral(r.FormValue("owner"))
+	rows, err := db.Query("SELECT * FROM " + r.FormValue("table") + " WHERE owner = " + owner)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+}
+`, 1),
+	)
+
+	DescribeTable("SQL injection through sqlx query methods",
+		func(code string, expected int) {
+			issues, err := analyzeModule("G701", gosec.NewConfig(), withHandler(code), "handler")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(issues).Should(HaveLen(expected))
+		},
+		Entry("tainted concatenation into Get and Queryx", `package handler
+
+import (
+	"net/http"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type User struct {
+	Name string
+}
+
+func Load(db *sqlx.DB, r *http.Request) {
+	var u User
+	_ = db.Get(&u, "SELECT name FROM users WHERE id = "+r.FormValue("id"))
+	rows, err := db.Queryx("SELECT * FROM users WHERE name = '" + r.FormValue("name") + "'")
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+}
+`, 2),
+		Entry("tainted concatenation into MustExec", `package handler
+
+import (
+	"net/http"
+
+	"github.com/jmoiron/sqlx"
+)
+
+func Purge(db *sqlx.DB, r *http.Request) {
+	db.MustExec("DELETE FROM sessions WHERE user_id = " + r.FormValue("id"))
+}
+`, 1),
+		Entry("bound parameters with Select and NamedExec", `package handler
+
+import (
+	"net/http"
+
+	"github.com/jmoiron/sqlx"
+)
+
+func Save(db *sqlx.DB, r *http.Request) {
+	var names []string
+	_ = db.Select(&names, "SELECT name FROM users WHERE team = ?", r.FormValue("team"))
+	_, _ = db.NamedExec("INSERT INTO users (name, team) VALUES (:name, :team)", map[string]interface{}{
+		"name": r.FormValue("name"),
+		"team": r.FormValue("team"),
+	})
+}
+`, 0),
+	)
+
+	DescribeTable("SQL injection through GORM raw SQL methods",
+		func(code string, expected int) {
+			issues, err := analyzeModule("G701", gosec.NewConfig(), withHandler(code), "handler")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(issues).Should(HaveLen(expected))
+		},
+		Entry("tainted concatenation into Raw", `package handler
+
+import (
+	"net/http"
+
+	"gorm.io/gorm"
+)
+
+type User struct {
+	Name string
+}
+
+func Load(db *gorm.DB, r *http.Request) {
+	var users []User
+	db.Raw("SELECT * FROM users WHERE name = '" + r.FormValue("name") + "'").Scan(&users)
+}
+`, 1),
+		Entry("tainted concatenation into Where and Exec", `package handler
+
+import (
+	"net/http"
+
+	"gorm.io/gorm"
+)
+
+type User struct {
+	Name string
+}
+
+func Purge(db *gorm.DB, r *http.Request) {
+	var users []User
+	db.Where("team = '" + r.FormValue("team") + "'").Find(&users)
+	db.Exec("DELETE FROM users WHERE id = " + r.FormValue("id"))
+}
+`, 2),
+		Entry("parameterized Where and Raw", `package handler
+
+import (
+	"net/http"
+
+	"gorm.io/gorm"
+)
+
+type User struct {
+	Name string
+}
+
+func Load(db *gorm.DB, r *http.Request) {
+	var users []User
+	db.Where("name = ?", r.FormValue("name")).Find(&users)
+	db.Raw("SELECT * FROM users WHERE team = ?", r.FormValue("team")).Scan(&users)
+}
+`, 0),
+	)
+
+	DescribeTable("NoSQL injection through MongoDB queries",
+		func(code string, expected int) {
+			issues, err := analyzeModule("G711", gosec.NewConfig(), withHandler(code), "handler")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(issues).Should(HaveLen(expected))
+		},
+		Entry("tainted $where filter", `package handler
+
+import (
+	"context"
+	"net/http"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func Search(ctx context.Context, coll *mongo.Collection, r *http.Request) {
+	filter := bson.M{"$where": "this.owner == '" + r.FormValue("owner") + "'"}
+	_, _ = coll.Find(ctx, filter)
+}
+`, 1),
+		Entry("typed _id filter", `package handler
+
+import (
+	"context"
+	"net/http"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func Load(ctx context.Context, coll *mongo.Collection, r *http.Request) {
+	_ = coll.FindOne(ctx, bson.M{"_id": r.FormValue("id")})
+}
+`, 0),
+		Entry("tainted $expr in a nested bson.D pipeline stage", `package handler
+
+import (
+	"context"
+	"net/http"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func Report(ctx context.Context, coll *mongo.Collection, r *http.Request) {
+	pipeline := bson.A{
+		bson.D{{Key: "$match", Value: bson.D{{Key: "$expr", Value: r.FormValue("expr")}}}},
+	}
+	_, _ = coll.Aggregate(ctx, pipeline)
+}
+`, 1),
+		Entry("tainted field name", `package handler
+
+import (
+	"context"
+	"net/http"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func Filter(ctx context.Context, coll *mongo.Collection, r *http.Request) {
+	_, _ = coll.Distinct(ctx, "name", bson.M{r.FormValue("field"): "x"})
+}
+`, 1),
+		Entry("filter parsed from an extended JSON query parameter", `package handler
+
+import (
+	"context"
+	"net/http"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func Query(ctx context.Context, coll *mongo.Collection, r *http.Request) {
+	var filter bson.D
+	if err := bson.UnmarshalExtJSON([]byte(r.FormValue("q")), false, &filter); err != nil {
+		return
+	}
+	_ = coll.FindOne(ctx, filter)
+}
+`, 1),
+		Entry("filter decoded from the request body", `package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func Query(ctx context.Context, coll *mongo.Collection, r *http.Request) {
+	var filter bson.M
+	if err := json.NewDecoder(r.Body).Decode(&filter); err != nil {
+		return
+	}
+	_, _ = coll.Find(ctx, filter)
+}
+`, 1),
+	)
+
+	DescribeTable("LDAP injection through go-ldap search filters",
+		func(code string, expected int) {
+			issues, err := analyzeModule("G713", gosec.NewConfig(), withHandler(code), "handler")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(issues).Should(HaveLen(expected))
+		},
+		Entry("concatenated filter", `package handler
+
+import (
+	"net/http"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+func Lookup(conn *ldap.Conn, r *http.Request) {
+	req := ldap.NewSearchRequest("dc=example,dc=com", ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
+		"(uid="+r.FormValue("user")+")", []string{"cn"}, nil)
+	_, _ = conn.Search(req)
+}
+`, 1),
+		Entry("escaped filter value", `package handler
+
+import (
+	"net/http"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+func Lookup(conn *ldap.Conn, r *http.Request) {
+	req := ldap.NewSearchRequest("dc=example,dc=com", ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
+		"(uid="+ldap.EscapeFilter(r.FormValue("user"))+")", []string{"cn"}, nil)
+	_, _ = conn.Search(req)
+}
+`, 0),
+	)
+})
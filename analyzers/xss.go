@@ -67,11 +67,34 @@ func XSS() taint.Config {
 				CheckArgs:     []int{1},
 				ArgTypeGuards: map[int]string{0: "net/http.ResponseWriter"},
 			},
-			// Template functions that unsafely inject untrusted content
-			{Package: "html/template", Method: "HTML"},
-			{Package: "html/template", Method: "HTMLAttr"},
-			{Package: "html/template", Method: "JS"},
-			{Package: "html/template", Method: "CSS"},
+			// text/template performs no escaping, so data it renders into an
+			// HTTP response is written verbatim.
+			{
+				Package:       "text/template",
+				Receiver:      "Template",
+				Method:        "Execute",
+				Pointer:       true,
+				CheckArgs:     []int{2},
+				ArgTypeGuards: map[int]string{1: "net/http.ResponseWriter"},
+			},
+			{
+				Package:       "text/template",
+				Receiver:      "Template",
+				Method:        "ExecuteTemplate",
+				Pointer:       true,
+				CheckArgs:     []int{3},
+				ArgTypeGuards: map[int]string{1: "net/http.ResponseWriter"},
+			},
+			// html/template trusts values of these types as safe content and
+			// renders them without escaping, so converting request data to
+			// one of them, e.g. template.HTML(v), bypasses auto-escaping.
+			{Package: "html/template", Method: "CSS", Conversion: true},
+			{Package: "html/template", Method: "HTML", Conversion: true},
+			{Package: "html/template", Method: "HTMLAttr", Conversion: true},
+			{Package: "html/template", Method: "JS", Conversion: true},
+			{Package: "html/template", Method: "JSStr", Conversion: true},
+			{Package: "html/template", Method: "Srcset", Conversion: true},
+			{Package: "html/template", Method: "URL", Conversion: true},
 		},
 		Sanitizers: []taint.Sanitizer{
 			// html.EscapeString escapes HTML special characters
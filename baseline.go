@@ -0,0 +1,132 @@
+package gosec
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/securego/gosec/v2/issue"
+)
+
+// Baseline holds the issues of a previous report so that only new issues are
+// reported. Issues are matched by rule ID, file and the text of the flagged
+// lines rather than by line number, so a known issue is still recognized
+// after code above it has moved.
+type Baseline struct {
+	root         string
+	fingerprints map[string]int // number of known issues per fingerprint
+}
+
+// baselineReport is the part of a JSON report that a baseline is built from.
+type baselineReport struct {
+	Issues []baselineIssue
+}
+
+type baselineIssue struct {
+	RuleID string `json:"rule_id"`
+	File   string `json:"file"`
+	Code   string `json:"code"`
+	Line   string `json:"line"`
+}
+
+// LoadBaseline reads a baseline from a report written with the json format.
+// File paths under root are compared relative to it, so that the report and
+// the issues may use either form.
+func LoadBaseline(r io.Reader, root string) (*Baseline, error) {
+	var report baselineReport
+	if err := json.NewDecoder(r).Decode(&report); err != nil {
+		return nil, fmt.Errorf("invalid baseline report: %w", err)
+	}
+	b := &Baseline{root: root, fingerprints: make(map[string]int)}
+	for _, iss := range report.Issues {
+		b.fingerprints[b.fingerprint(iss.RuleID, iss.File, iss.Code, iss.Line)]++
+	}
+	return b, nil
+}
+
+// FilterIssues removes the issues that are in the baseline. Each baseline
+// entry hides one issue, so a copy of a known issue is still reported.
+// Returns the new issues and the count of removed issues.
+func (b *Baseline) FilterIssues(issues []*issue.Issue) ([]*issue.Issue, int) {
+	if b == nil || len(b.fingerprints) == 0 || len(issues) == 0 {
+		return issues, 0
+	}
+
+	remaining := make(map[string]int, len(b.fingerprints))
+	for fp, count := range b.fingerprints {
+		remaining[fp] = count
+	}
+
+	filtered := make([]*issue.Issue, 0, len(issues))
+	known := 0
+	for _, iss := range issues {
+		fp := b.fingerprint(iss.RuleID, iss.File, iss.Code, iss.Line)
+		if remaining[fp] > 0 {
+			remaining[fp]--
+			known++
+			continue
+		}
+		filtered = append(filtered, iss)
+	}
+	return filtered, known
+}
+
+// fingerprint identifies an issue independently of its line number.
+func (b *Baseline) fingerprint(ruleID, file, code, line string) string {
+	if b.root != "" {
+		if rel, err := filepath.Rel(b.root, file); err == nil && !strings.HasPrefix(rel, "..") {
+			file = rel
+		}
+	}
+	h := sha256.New()
+	for _, part := range []string{ruleID, filepath.ToSlash(file), flaggedCode(code, line)} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// flaggedCode returns the lines of an issue's code snippet that the issue
+// points to, without their line numbers and surrounding whitespace. The
+// lines around them are left out, so that unrelated edits do not change it.
+// The whole snippet is used when the issue line cannot be found in it.
+func flaggedCode(code, line string) string {
+	first, last, ok := lineRange(line)
+	var lines []string
+	for _, snippetLine := range strings.Split(code, "\n") {
+		num, text, found := strings.Cut(snippetLine, ": ")
+		n, err := strconv.Atoi(num)
+		if !found || err != nil {
+			continue
+		}
+		if ok && n >= first && n <= last {
+			lines = append(lines, strings.Join(strings.Fields(text), " "))
+		}
+	}
+	if len(lines) == 0 {
+		return strings.Join(strings.Fields(code), " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// lineRange parses an issue line such as "12" or "12-14".
+func lineRange(line string) (int, int, bool) {
+	start, end, isRange := strings.Cut(line, "-")
+	first, err := strconv.Atoi(start)
+	if err != nil {
+		return 0, 0, false
+	}
+	if !isRange {
+		return first, first, true
+	}
+	last, err := strconv.Atoi(end)
+	if err != nil {
+		return 0, 0, false
+	}
+	return first, last, true
+}
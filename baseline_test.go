@@ -0,0 +1,102 @@
+package gosec_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/securego/gosec/v2"
+	"github.com/securego/gosec/v2/issue"
+)
+
+// newBaselineIssue creates an issue on the given line whose code snippet has
+// a line of context on each side.
+func newBaselineIssue(ruleID, file string, line int, code string) *issue.Issue {
+	snippet := fmt.Sprintf("%d: func handler() {\n%d: %s\n%d: }\n", line-1, line, code, line+1)
+	return &issue.Issue{
+		RuleID: ruleID,
+		File:   file,
+		Line:   fmt.Sprint(line),
+		Code:   snippet,
+	}
+}
+
+// writeBaseline returns a json report of issues.
+func writeBaseline(issues ...*issue.Issue) *bytes.Buffer {
+	var buf bytes.Buffer
+	err := json.NewEncoder(&buf).Encode(gosec.NewReportInfo(issues, &gosec.Metrics{}, nil))
+	Expect(err).NotTo(HaveOccurred())
+	return &buf
+}
+
+var _ = Describe("Baseline", func() {
+	const query = `db.Query("SELECT * FROM users WHERE name = '" + name + "'")`
+
+	It("should reject a report that is not json", func() {
+		_, err := gosec.LoadBaseline(strings.NewReader("not json"), "/src")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("invalid baseline report"))
+	})
+
+	It("should hide known issues and report new ones", func() {
+		baseline, err := gosec.LoadBaseline(writeBaseline(
+			newBaselineIssue("G701", "/src/app/users.go", 12, query),
+			newBaselineIssue("G702", "/src/app/exec.go", 30, `exec.Command("sh", "-c", cmd)`),
+		), "/src")
+		Expect(err).NotTo(HaveOccurred())
+
+		added := newBaselineIssue("G701", "/src/app/orders.go", 8, query)
+		issues, known := baseline.FilterIssues([]*issue.Issue{
+			newBaselineIssue("G701", "/src/app/users.go", 12, query),
+			newBaselineIssue("G702", "/src/app/exec.go", 30, `exec.Command("sh", "-c", cmd)`),
+			added,
+		})
+		Expect(known).To(Equal(2))
+		Expect(issues).To(ConsistOf(added))
+	})
+
+	It("should match known issues that moved to another line", func() {
+		baseline, err := gosec.LoadBaseline(writeBaseline(
+			newBaselineIssue("G701", "/src/app/users.go", 12, query),
+		), "/src")
+		Expect(err).NotTo(HaveOccurred())
+
+		issues, known := baseline.FilterIssues([]*issue.Issue{
+			newBaselineIssue("G701", "/src/app/users.go", 17, "  "+query),
+		})
+		Expect(known).To(Equal(1))
+		Expect(issues).To(BeEmpty())
+	})
+
+	It("should report issues of another rule or with changed code", func() {
+		baseline, err := gosec.LoadBaseline(writeBaseline(
+			newBaselineIssue("G701", "/src/app/users.go", 12, query),
+		), "/src")
+		Expect(err).NotTo(HaveOccurred())
+
+		issues, known := baseline.FilterIssues([]*issue.Issue{
+			newBaselineIssue("G706", "/src/app/users.go", 12, query),
+			newBaselineIssue("G701", "/src/app/users.go", 12, strings.Replace(query, "users", "admins", 1)),
+		})
+		Expect(known).To(Equal(0))
+		Expect(issues).To(HaveLen(2))
+	})
+
+	It("should report copies of a known issue beyond the baseline count", func() {
+		baseline, err := gosec.LoadBaseline(writeBaseline(
+			newBaselineIssue("G701", "/src/app/users.go", 12, query),
+		), "/src")
+		Expect(err).NotTo(HaveOccurred())
+
+		issues, known := baseline.FilterIssues([]*issue.Issue{
+			newBaselineIssue("G701", "/src/app/users.go", 12, query),
+			newBaselineIssue("G701", "/src/app/users.go", 40, query),
+		})
+		Expect(known).To(Equal(1))
+		Expect(issues).To(HaveLen(1))
+	})
+})
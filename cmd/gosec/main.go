@@ -64,6 +64,9 @@ USAGE:
 
 	# Exclude all rules from scripts directory
 	$ gosec --exclude-rules="scripts/.*:*" ./...
+
+	# Report only the issues that are not in a previous json report
+	$ gosec -baseline=baseline.json ./...
 `
 	// Environment variable for AI API key.
 	aiAPIKeyEnv = "GOSEC_AI_API_KEY" // #nosec G101
@@ -94,9 +97,15 @@ var (
 Example: "cmd/.*:G204,G304;test/.*:G101"
 Use "*" to exclude all rules for a path: "scripts/.*:*"`)
 
+	// Issues of a previous report to leave out
+	flagBaseline = flag.String("baseline", "", "Path to a json report of known issues. Only issues not found in it are reported")
+
 	// show ignored
 	flagShowIgnored = flag.Bool("show-ignored", false, "If enabled, ignored issues are printed")
 
+	// report the over-approximations of taint findings
+	flagTaintDiagnostics = flag.Bool("taint-diagnostics", false, "If enabled, taint issues list the over-approximations they rely on")
+
 	// format output
 	flagFormat = flag.String("fmt", "text", "Set output format. Valid options are: json, yaml, csv, junit-xml, html, sonarqube, golint, sarif or text")
 
@@ -236,6 +245,9 @@ func loadConfig(configFile string) (gosec.Config, error) {
 	if *flagShowIgnored {
 		config.SetGlobal(gosec.ShowIgnored, "true")
 	}
+	if *flagTaintDiagnostics {
+		config.SetGlobal(gosec.TaintDiagnostics, "true")
+	}
 	if *flagAlternativeNoSec != "" {
 		config.SetGlobal(gosec.NoSecAlternative, *flagAlternativeNoSec)
 	}
@@ -390,6 +402,21 @@ func buildPathExclusionFilter(config gosec.Config, cliFlag string) (*gosec.PathE
 	return gosec.NewPathExclusionFilter(allRules)
 }
 
+// loadBaseline reads the baseline report. Its file paths are compared
+// relative to the working directory.
+func loadBaseline(filename string) (*gosec.Baseline, error) {
+	root, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(filename) // #nosec G304
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close() // #nosec G307
+	return gosec.LoadBaseline(file, root)
+}
+
 func main() {
 	os.Exit(run())
 }
@@ -503,6 +530,12 @@ func run() int {
 		return exitFailure
 	}
 
+	// Fail on invalid overrides rather than reporting with the defaults
+	if _, err := config.GetRuleOverrides(); err != nil {
+		logger.Printf("Invalid rule-overrides in config: %v", err)
+		return exitFailure
+	}
+
 	// Create the analyzer
 	analyzer := gosec.NewAnalyzer(config, *flagScanTests, *flagExcludeGenerated, *flagTrackSuppressions, *flagConcurrency, logger)
 	analyzer.LoadRules(ruleList.RulesInfo())
@@ -549,6 +582,20 @@ func run() int {
 		logger.Printf("Excluded %d issues by path-based rules", pathExcludedCount)
 	}
 
+	// Leave out the issues of the baseline
+	if *flagBaseline != "" {
+		baseline, err := loadBaseline(*flagBaseline)
+		if err != nil {
+			logger.Printf("Failed to load baseline: %v", err)
+			return exitFailure
+		}
+		var knownCount int
+		issues, knownCount = baseline.FilterIssues(issues)
+		if knownCount > 0 {
+			logger.Printf("Excluded %d issues found in the baseline", knownCount)
+		}
+	}
+
 	// Sort the issue by severity
 	if *flagSortIssues {
 		sortIssues(issues)
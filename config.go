@@ -5,6 +5,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"strings"
+
+	"github.com/securego/gosec/v2/issue"
 )
 
 const (
@@ -13,6 +16,8 @@ const (
 	Globals = "global"
 	// ExcludeRulesKey is the config key for path-based rule exclusions
 	ExcludeRulesKey = "exclude-rules"
+	// RuleOverridesKey is the config key for per-rule severity and confidence overrides
+	RuleOverridesKey = "rule-overrides"
 )
 
 // GlobalOption defines the name of the global options
@@ -33,6 +38,12 @@ const (
 	IncludeRules GlobalOption = "include"
 	// SSA global option to enable go analysis framework with SSA support
 	SSA GlobalOption = "ssa"
+	// TaintTests global option to run the taint analysis rules on test files,
+	// which the other rules only scan when tests are enabled
+	TaintTests GlobalOption = "taint-tests"
+	// TaintDiagnostics global option to report the over-approximations, such
+	// as a map tainted as a whole, that the taint analysis findings rely on
+	TaintDiagnostics GlobalOption = "taint-diagnostics"
 )
 
 // NoSecTag returns the tag used to disable gosec for a line of code.
@@ -172,3 +183,69 @@ func (c Config) SetExcludeRules(rules []PathExcludeRule) {
 	}
 	c[ExcludeRulesKey] = rules
 }
+
+// RuleOverride adjusts how the issues of a rule are reported
+type RuleOverride struct {
+	Severity      string `json:"severity,omitempty"`       // Severity reported instead of the rule's own
+	MinConfidence string `json:"min-confidence,omitempty"` // Issues with a lower confidence are not reported
+}
+
+// GetRuleOverrides retrieves the per-rule overrides from the configuration,
+// keyed by rule ID. Returns nil if no overrides are configured.
+func (c Config) GetRuleOverrides() (map[string]RuleOverride, error) {
+	if c == nil {
+		return nil, nil
+	}
+
+	rawOverrides, exists := c[RuleOverridesKey]
+	if !exists {
+		return nil, nil
+	}
+
+	overridesJSON, err := json.Marshal(rawOverrides)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal rule-overrides: %w", err)
+	}
+
+	var overrides map[string]RuleOverride
+	if err := json.Unmarshal(overridesJSON, &overrides); err != nil {
+		return nil, fmt.Errorf("failed to parse rule-overrides: %w", err)
+	}
+
+	for id, override := range overrides {
+		if override.Severity != "" {
+			if _, err := parseScore(override.Severity); err != nil {
+				return nil, fmt.Errorf("rule-overrides[%s]: invalid severity: %w", id, err)
+			}
+		}
+		if override.MinConfidence != "" {
+			if _, err := parseScore(override.MinConfidence); err != nil {
+				return nil, fmt.Errorf("rule-overrides[%s]: invalid min-confidence: %w", id, err)
+			}
+		}
+	}
+
+	return overrides, nil
+}
+
+// SetRuleOverrides sets the per-rule overrides in the configuration.
+func (c Config) SetRuleOverrides(overrides map[string]RuleOverride) {
+	if c == nil {
+		return
+	}
+	c[RuleOverridesKey] = overrides
+}
+
+// parseScore converts a low, medium or high value into a score
+func parseScore(value string) (issue.Score, error) {
+	switch strings.ToLower(value) {
+	case "low":
+		return issue.Low, nil
+	case "medium":
+		return issue.Medium, nil
+	case "high":
+		return issue.High, nil
+	default:
+		return issue.Low, fmt.Errorf("value %q not valid. Valid options: low, medium, high", value)
+	}
+}
@@ -206,4 +206,38 @@ var _ = Describe("Configuration", func() {
 			Expect(rules).Should(BeNil())
 		})
 	})
+
+	Context("when managing rule overrides", func() {
+		It("should read rule overrides from a configuration file", func() {
+			config := `{"rule-overrides": {"G118": {"severity": "high"}, "G701": {"min-confidence": "high"}}}`
+			_, err := configuration.ReadFrom(strings.NewReader(config))
+			Expect(err).ShouldNot(HaveOccurred())
+
+			overrides, err := configuration.GetRuleOverrides()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(overrides).Should(HaveLen(2))
+			Expect(overrides["G118"]).Should(Equal(gosec.RuleOverride{Severity: "high"}))
+			Expect(overrides["G701"]).Should(Equal(gosec.RuleOverride{MinConfidence: "high"}))
+		})
+
+		It("should return nil without rule overrides", func() {
+			overrides, err := configuration.GetRuleOverrides()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(overrides).Should(BeNil())
+		})
+
+		It("should reject an invalid severity", func() {
+			configuration.SetRuleOverrides(map[string]gosec.RuleOverride{"G118": {Severity: "critical"}})
+
+			_, err := configuration.GetRuleOverrides()
+			Expect(err).Should(MatchError(ContainSubstring("rule-overrides[G118]: invalid severity")))
+		})
+
+		It("should reject an invalid minimum confidence", func() {
+			configuration.SetRuleOverrides(map[string]gosec.RuleOverride{"G701": {MinConfidence: "certain"}})
+
+			_, err := configuration.GetRuleOverrides()
+			Expect(err).Should(MatchError(ContainSubstring("rule-overrides[G701]: invalid min-confidence")))
+		})
+	})
 })
@@ -18,6 +18,11 @@ const (
 )
 
 var idWeaknesses = map[string]*Weakness{
+	"15": {
+		ID:          "15",
+		Description: "One or more system settings or configuration elements can be externally controlled by a user.",
+		Name:        "External Control of System or Configuration Setting",
+	},
 	"22": {
 		ID:          "22",
 		Description: "The software uses external input to construct a pathname that is intended to identify a file or directory that is located underneath a restricted parent directory, but the software does not properly neutralize special elements within the pathname that can cause the pathname to resolve to a location that is outside of the restricted directory.",
@@ -43,6 +48,11 @@ var idWeaknesses = map[string]*Weakness{
 		Description: "The software constructs all or part of an SQL command using externally-influenced input from an upstream component, but it does not neutralize or incorrectly neutralizes special elements that could modify the intended SQL command when it is sent to a downstream component.",
 		Name:        "Improper Neutralization of Special Elements used in an SQL Command ('SQL Injection')",
 	},
+	"90": {
+		ID:          "90",
+		Description: "The software constructs all or part of an LDAP query using externally-influenced input from an upstream component, but it does not neutralize or incorrectly neutralizes special elements that could modify the intended LDAP query when it is sent to a downstream component.",
+		Name:        "Improper Neutralization of Special Elements used in an LDAP Query ('LDAP Injection')",
+	},
 	"93": {
 		ID:          "93",
 		Description: "The software does not properly neutralize CRLF sequences before using externally-influenced input in protocol elements that rely on CRLF as delimiters, allowing attackers to inject additional commands or headers.",
@@ -53,6 +63,11 @@ var idWeaknesses = map[string]*Weakness{
 		Description: "The software constructs all or part of a code segment using externally-influenced input from an upstream component, but it does not neutralize or incorrectly neutralizes special elements that could modify the syntax or behavior of the intended code segment.",
 		Name:        "Improper Control of Generation of Code ('Code Injection')",
 	},
+	"113": {
+		ID:          "113",
+		Description: "The software receives data from an upstream component, but does not neutralize or incorrectly neutralizes CR and LF characters before the data is included in outgoing HTTP headers.",
+		Name:        "Improper Neutralization of CRLF Sequences in HTTP Headers ('HTTP Response Splitting')",
+	},
 	"118": {
 		ID:          "118",
 		Description: "The software does not restrict or incorrectly restricts operations within the boundaries of a resource that is accessed using an index or pointer, such as memory or files.",
@@ -188,6 +203,16 @@ var idWeaknesses = map[string]*Weakness{
 		Description: "The web server receives a URL or similar request from an upstream component and retrieves the contents of this URL, but it does not sufficiently ensure that the request is being sent to the expected destination.",
 		Name:        "Server-Side Request Forgery (SSRF)",
 	},
+	"943": {
+		ID:          "943",
+		Description: "The application generates a query intended to access or manipulate data in a data store such as a database, but it does not neutralize or incorrectly neutralizes special elements that can modify the intended logic of the query.",
+		Name:        "Improper Neutralization of Special Elements in Data Query Logic",
+	},
+	"1333": {
+		ID:          "1333",
+		Description: "The product uses a regular expression with an inefficient, possibly exponential worst-case computational complexity that consumes excessive CPU cycles.",
+		Name:        "Inefficient Regular Expression Complexity",
+	},
 }
 
 // Get Retrieves a CWE weakness by it's id
@@ -117,22 +117,90 @@ var ruleToCWE = map[string]string{
 	"G705": "79",
 	"G706": "117",
 	"G710": "601",
+	"G711": "943",
+	"G712": "1333",
+	"G713": "90",
+	"G714": "15",
+	"G715": "134",
+	"G716": "113",
 }
 
 // Issue is returned by a gosec rule if it discovers an issue with the scanned code.
 type Issue struct {
-	Severity     Score             `json:"severity"`          // issue severity (how problematic it is)
-	Confidence   Score             `json:"confidence"`        // issue confidence (how sure we are we found it)
-	Cwe          *cwe.Weakness     `json:"cwe"`               // Cwe associated with RuleID
-	RuleID       string            `json:"rule_id"`           // Human readable explanation
-	What         string            `json:"details"`           // Human readable explanation
-	File         string            `json:"file"`              // File name we found it in
-	Code         string            `json:"code"`              // Impacted code line
-	Line         string            `json:"line"`              // Line number in file
-	Col          string            `json:"column"`            // Column number in line
-	NoSec        bool              `json:"nosec"`             // true if the issue is nosec
-	Suppressions []SuppressionInfo `json:"suppressions"`      // Suppression info of the issue
-	Autofix      string            `json:"autofix,omitempty"` // Proposed auto fix the issue
+	Severity     Score             `json:"severity"`              // issue severity (how problematic it is)
+	Confidence   Score             `json:"confidence"`            // issue confidence (how sure we are we found it)
+	Cwe          *cwe.Weakness     `json:"cwe"`                   // Cwe associated with RuleID
+	RuleID       string            `json:"rule_id"`               // Human readable explanation
+	What         string            `json:"details"`               // Human readable explanation
+	File         string            `json:"file"`                  // File name we found it in
+	Code         string            `json:"code"`                  // Impacted code line
+	Line         string            `json:"line"`                  // Line number in file
+	Col          string            `json:"column"`                // Column number in line
+	NoSec        bool              `json:"nosec"`                 // true if the issue is nosec
+	Suppressions []SuppressionInfo `json:"suppressions"`          // Suppression info of the issue
+	Autofix      string            `json:"autofix,omitempty"`     // Proposed auto fix the issue
+	Flow         []FlowStep        `json:"flow,omitempty"`        // Data flow from the source to the issue
+	Diagnostics  []FlowStep        `json:"diagnostics,omitempty"` // Over-approximations the issue relies on
+	Subcategory  string            `json:"subcategory,omitempty"` // Kind of issue within the rule, when its remediation differs
+	TaintFlow    *TaintFlow        `json:"taintFlow,omitempty"`   // Data flow from the source to the issue, in the versioned schema
+}
+
+// FlowStep is one step of the data flow that leads to an issue, such as the
+// steps from user input to a SQL query found by a taint analysis rule.
+type FlowStep struct {
+	File        string `json:"file"`           // File name of the step
+	Line        string `json:"line"`           // Line number in file
+	Col         string `json:"column"`         // Column number in line
+	Description string `json:"description"`    // Short description of the step
+	Kind        string `json:"kind,omitempty"` // SSA category of the step, one of the FlowKind constants
+}
+
+// Kinds of data flow steps, named after the SSA values they stand for
+const (
+	FlowKindAssign    = "assign"    // local variable, or values merged by assignments
+	FlowKindCall      = "call"      // function call, including sources and sinks
+	FlowKindParameter = "parameter" // function parameter
+	FlowKindCapture   = "capture"   // variable captured by a closure
+	FlowKindField     = "field"     // struct field
+	FlowKindIndex     = "index"     // element of an array, slice, map or string
+	FlowKindSlice     = "slice"     // slice expression
+	FlowKindConvert   = "convert"   // conversion, type assertion or interface value
+	FlowKindOperation = "operation" // arithmetic operation or concatenation
+	FlowKindLoad      = "load"      // pointer dereference
+	FlowKindReceive   = "receive"   // channel receive
+	FlowKindExtract   = "extract"   // result of a call returning several values
+	FlowKindGlobal    = "global"    // package-level variable
+	FlowKindContainer = "container" // map, channel or slice made in place
+	FlowKindValue     = "value"     // any other value
+)
+
+// TaintFlowVersion is the version of the TaintFlow schema. It changes only
+// when a field is removed or changes meaning.
+const TaintFlowVersion = 1
+
+// TaintFlow is the data flow of a taint finding in a stable, versioned
+// schema: the positions of the source and the sink, and every step from one
+// to the other in order, both included.
+type TaintFlow struct {
+	Version     int        `json:"version"`               // Version of the schema, TaintFlowVersion
+	Source      FlowStep   `json:"source"`                // Value where the tainted data enters
+	Sink        FlowStep   `json:"sink"`                  // Call receiving the tainted data
+	Steps       []FlowStep `json:"steps"`                 // Steps from the source to the sink
+	MergedSinks []FlowStep `json:"mergedSinks,omitempty"` // Other sinks reached by the source, when findings are deduplicated
+}
+
+// NewTaintFlow returns the TaintFlow of the given flow steps, or nil when
+// there are none.
+func NewTaintFlow(steps []FlowStep) *TaintFlow {
+	if len(steps) == 0 {
+		return nil
+	}
+	return &TaintFlow{
+		Version: TaintFlowVersion,
+		Source:  steps[0],
+		Sink:    steps[len(steps)-1],
+		Steps:   steps,
+	}
 }
 
 // SuppressionInfo object is to record the kind and the justification that used
@@ -142,6 +210,22 @@ type SuppressionInfo struct {
 	Justification string `json:"justification"`
 }
 
+// SourceStep returns the step of the issue's data flow where the tainted data
+// is read: its first step that is not a parameter, such as the
+// r.FormValue("name") call on a request parameter, or its last step when all
+// of them are. It reports false when the issue has no data flow.
+func (i *Issue) SourceStep() (FlowStep, bool) {
+	if len(i.Flow) == 0 {
+		return FlowStep{}, false
+	}
+	for _, step := range i.Flow {
+		if step.Kind != FlowKindParameter {
+			return step, true
+		}
+	}
+	return i.Flow[len(i.Flow)-1], true
+}
+
 // FileLocation point out the file path and line number in file
 func (i *Issue) FileLocation() string {
 	return fmt.Sprintf("%s:%s", i.File, i.Line)
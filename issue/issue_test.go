@@ -285,6 +285,25 @@ func main() {
 			Expect(iss.Suppressions[0].Justification).Should(Equal("false positive"))
 			Expect(iss.Suppressions[1].Kind).Should(Equal("external"))
 		})
+
+		It("should return the first step of the flow that is not a parameter as its source", func() {
+			iss := &issue.Issue{
+				Flow: []issue.FlowStep{
+					{Line: "8", Kind: issue.FlowKindParameter},
+					{Line: "9", Kind: issue.FlowKindCall},
+					{Line: "3", Kind: issue.FlowKindParameter},
+					{Line: "4", Kind: issue.FlowKindCall},
+				},
+			}
+			source, ok := iss.SourceStep()
+			Expect(ok).Should(BeTrue())
+			Expect(source.Line).Should(Equal("9"))
+		})
+
+		It("should return no source step without a flow", func() {
+			_, ok := (&issue.Issue{}).SourceStep()
+			Expect(ok).Should(BeFalse())
+		})
 	})
 
 	Describe("GetLine", func() {
@@ -1,12 +1,19 @@
 package json
 
 import (
+	_ "embed"
 	"encoding/json"
 	"io"
 
 	"github.com/securego/gosec/v2"
 )
 
+// TaintFlowSchema is the JSON schema of the taintFlow field of the issues
+// found by taint analysis rules.
+//
+//go:embed taint_flow.schema.json
+var TaintFlowSchema []byte
+
 // WriteReport write a report in json format to the output writer
 func WriteReport(w io.Writer, data *gosec.ReportInfo) error {
 	raw, err := json.MarshalIndent(data, "", "\t")
@@ -7,10 +7,13 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/santhosh-tekuri/jsonschema/v6"
 
 	"github.com/securego/gosec/v2"
+	"github.com/securego/gosec/v2/analyzers"
 	"github.com/securego/gosec/v2/issue"
 	jsonreport "github.com/securego/gosec/v2/report/json"
+	"github.com/securego/gosec/v2/testutils"
 )
 
 func TestJSON(t *testing.T) {
@@ -108,6 +111,69 @@ var _ = Describe("JSON Writer", func() {
 			Expect(stats["found"]).To(BeNumerically("==", 5))
 		})
 
+		It("should write the taint flow of taint findings in the versioned schema", func() {
+			logger, _ := testutils.NewLogger()
+			analyzer := gosec.NewAnalyzer(gosec.NewConfig(), false, false, false, 1, logger)
+			analyzer.LoadAnalyzers(analyzers.Generate(false, analyzers.NewAnalyzerFilter(false, "G701")).AnalyzersInfo())
+			pkg := testutils.NewTestPackage()
+			defer pkg.Close()
+			pkg.AddFile("app.go", `
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+func handler(db *sql.DB, r *http.Request) {
+	name := r.FormValue("name")
+	query := "SELECT * FROM users WHERE name = '" + name + "'"
+	rows, err := db.Query(query)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+}
+`)
+			Expect(pkg.Build()).Should(Succeed())
+			Expect(analyzer.Process(nil, pkg.Path)).Should(Succeed())
+			issues, stats, errors := analyzer.Report()
+			Expect(issues).Should(HaveLen(1))
+
+			buf := new(bytes.Buffer)
+			err := jsonreport.WriteReport(buf, gosec.NewReportInfo(issues, stats, errors))
+			Expect(err).ShouldNot(HaveOccurred())
+
+			var result struct {
+				Issues []map[string]any
+			}
+			Expect(json.Unmarshal(buf.Bytes(), &result)).Should(Succeed())
+			Expect(result.Issues).Should(HaveLen(1))
+			Expect(result.Issues[0]).Should(HaveKey("taintFlow"))
+
+			schemaDoc, err := jsonschema.UnmarshalJSON(bytes.NewReader(jsonreport.TaintFlowSchema))
+			Expect(err).ShouldNot(HaveOccurred())
+			compiler := jsonschema.NewCompiler()
+			Expect(compiler.AddResource("taint_flow.schema.json", schemaDoc)).Should(Succeed())
+			schema, err := compiler.Compile("taint_flow.schema.json")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(schema.Validate(result.Issues[0]["taintFlow"])).Should(Succeed())
+
+			flow := result.Issues[0]["taintFlow"].(map[string]any)
+			steps := flow["steps"].([]any)
+			Expect(len(steps)).Should(BeNumerically(">=", 3))
+			Expect(steps[0]).Should(Equal(flow["source"]))
+			Expect(steps[len(steps)-1]).Should(Equal(flow["sink"]))
+			Expect(flow["source"]).Should(HaveKeyWithValue("kind", issue.FlowKindParameter))
+			Expect(flow["source"]).Should(HaveKeyWithValue("line", "9"))
+			Expect(flow["sink"]).Should(HaveKeyWithValue("kind", issue.FlowKindCall))
+			Expect(flow["sink"]).Should(HaveKeyWithValue("line", "12"))
+			Expect(steps).Should(ContainElement(And(
+				HaveKeyWithValue("kind", issue.FlowKindOperation),
+				HaveKeyWithValue("line", "11"),
+			)))
+		})
+
 		It("should escape special characters", func() {
 			data := &gosec.ReportInfo{
 				Errors: map[string][]gosec.Error{},
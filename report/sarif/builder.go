@@ -129,6 +129,33 @@ func (r *Result) WithLocations(locations ...*Location) *Result {
 	return r
 }
 
+// WithCodeFlows define the current result's code flows
+func (r *Result) WithCodeFlows(codeFlows ...*CodeFlow) *Result {
+	r.CodeFlows = codeFlows
+	return r
+}
+
+// NewCodeFlow instantiate a CodeFlow
+func NewCodeFlow(threadFlows ...*ThreadFlow) *CodeFlow {
+	return &CodeFlow{
+		ThreadFlows: threadFlows,
+	}
+}
+
+// NewThreadFlow instantiate a ThreadFlow
+func NewThreadFlow(locations ...*ThreadFlowLocation) *ThreadFlow {
+	return &ThreadFlow{
+		Locations: locations,
+	}
+}
+
+// NewThreadFlowLocation instantiate a ThreadFlowLocation
+func NewThreadFlowLocation(location *Location) *ThreadFlowLocation {
+	return &ThreadFlowLocation{
+		Location: location,
+	}
+}
+
 // NewLocation instantiate a Location
 func NewLocation(physicalLocation *PhysicalLocation) *Location {
 	return &Location{
@@ -136,6 +163,12 @@ func NewLocation(physicalLocation *PhysicalLocation) *Location {
 	}
 }
 
+// WithMessage defines the Message for the current Location
+func (l *Location) WithMessage(message *Message) *Location {
+	l.Message = message
+	return l
+}
+
 // NewPhysicalLocation instantiate a PhysicalLocation
 func NewPhysicalLocation(artifactLocation *ArtifactLocation, region *Region) *PhysicalLocation {
 	return &PhysicalLocation{
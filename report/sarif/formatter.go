@@ -50,6 +50,14 @@ func GenerateReport(rootPaths []string, data *gosec.ReportInfo) (*Report, error)
 			issue.Autofix,
 		).WithLocations(location)
 
+		if len(issue.Flow) > 0 {
+			codeFlow, err := parseSarifCodeFlow(issue, rootPaths)
+			if err != nil {
+				return nil, err
+			}
+			result.WithCodeFlows(codeFlow)
+		}
+
 		results = append(results, result)
 	}
 
@@ -180,15 +188,37 @@ func parseSarifLocation(i *issue.Issue, rootPaths []string) (*Location, error) {
 	if err != nil {
 		return nil, err
 	}
-	artifactLocation := parseSarifArtifactLocation(i, rootPaths)
+	artifactLocation := parseSarifArtifactLocation(i.File, rootPaths)
 	return NewLocation(NewPhysicalLocation(artifactLocation, region)), nil
 }
 
-func parseSarifArtifactLocation(i *issue.Issue, rootPaths []string) *ArtifactLocation {
+// parseSarifCodeFlow return SARIF code flow struct with a single thread flow
+// going through the steps of the issue's data flow
+func parseSarifCodeFlow(i *issue.Issue, rootPaths []string) (*CodeFlow, error) {
+	locations := make([]*ThreadFlowLocation, 0, len(i.Flow))
+	for _, step := range i.Flow {
+		line, err := strconv.Atoi(step.Line)
+		if err != nil {
+			return nil, err
+		}
+		col, err := strconv.Atoi(step.Col)
+		if err != nil {
+			return nil, err
+		}
+		artifactLocation := parseSarifArtifactLocation(step.File, rootPaths)
+		region := NewRegion(line, line, col, col, "go")
+		location := NewLocation(NewPhysicalLocation(artifactLocation, region)).
+			WithMessage(NewMessage(step.Description))
+		locations = append(locations, NewThreadFlowLocation(location))
+	}
+	return NewCodeFlow(NewThreadFlow(locations...)), nil
+}
+
+func parseSarifArtifactLocation(file string, rootPaths []string) *ArtifactLocation {
 	var filePath string
 	for _, rootPath := range rootPaths {
-		if strings.HasPrefix(i.File, rootPath) {
-			filePath = strings.Replace(i.File, rootPath+"/", "", 1)
+		if strings.HasPrefix(file, rootPath) {
+			filePath = strings.Replace(file, rootPath+"/", "", 1)
 		}
 	}
 	return NewArtifactLocation(filePath)
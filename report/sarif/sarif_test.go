@@ -319,5 +319,79 @@ var _ = Describe("Sarif Formatter", func() {
 			Expect(resultRuleIndexes).Should(Equal(driverRuleIndexes))
 			Expect(validateSarifSchema(sarifReport)).To(Succeed())
 		})
+
+		It("sarif formatted report should contain the code flow of taint findings", func() {
+			ruleID := "G701"
+			taintIssue := []*issue.Issue{
+				{
+					File:       "/home/src/project/app.go",
+					Line:       "11",
+					Col:        "21",
+					RuleID:     ruleID,
+					What:       "SQL injection via taint analysis",
+					Confidence: issue.High,
+					Severity:   issue.High,
+					Code:       "11: rows, _ := db.Query(q)",
+					Cwe:        issue.GetCweByRule(ruleID),
+					Flow: []issue.FlowStep{
+						{File: "/home/src/project/app.go", Line: "9", Col: "19", Description: `t0 = (*net/http.Request).FormValue(r, "id":string)`},
+						{File: "/home/src/project/app.go", Line: "10", Col: "41", Description: `t1 = "SELECT * FROM use...":string + t0`},
+						{File: "/home/src/project/app.go", Line: "11", Col: "21", Description: "t2 = (*database/sql.DB).Query(db, t1, nil:[]any...)"},
+					},
+				},
+			}
+			reportInfo := gosec.NewReportInfo(taintIssue, &gosec.Metrics{}, map[string][]gosec.Error{}).WithVersion("v2.22.0")
+
+			sarifReport, err := sarif.GenerateReport([]string{"/home/src/project"}, reportInfo)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(validateSarifSchema(sarifReport)).To(Succeed())
+
+			result := sarifReport.Runs[0].Results[0]
+			Expect(result.Locations).To(HaveLen(1))
+			Expect(result.CodeFlows).To(HaveLen(1))
+			Expect(result.CodeFlows[0].ThreadFlows).To(HaveLen(1))
+			locations := result.CodeFlows[0].ThreadFlows[0].Locations
+			Expect(locations).To(HaveLen(3))
+
+			source := locations[0].Location
+			Expect(source.PhysicalLocation.ArtifactLocation.URI).To(Equal("app.go"))
+			Expect(source.PhysicalLocation.Region.StartLine).To(Equal(9))
+			Expect(source.PhysicalLocation.Region.StartColumn).To(Equal(19))
+			Expect(source.Message.Text).To(ContainSubstring("FormValue"))
+
+			sink := locations[2].Location
+			Expect(sink.PhysicalLocation.Region.StartLine).To(Equal(11))
+			Expect(sink.PhysicalLocation.Region.StartColumn).To(Equal(21))
+			Expect(sink.Message.Text).To(ContainSubstring("Query"))
+		})
+
+		It("sarif formatted report should not include code flows for issues without flow", func() {
+			ruleID := "G304"
+			plainIssue := []*issue.Issue{
+				{
+					File:       "/home/src/project/test.go",
+					Line:       "10",
+					Col:        "5",
+					RuleID:     ruleID,
+					What:       "Potential file inclusion via variable",
+					Confidence: issue.High,
+					Severity:   issue.High,
+					Code:       "10: os.ReadFile(path)",
+					Cwe:        issue.GetCweByRule(ruleID),
+				},
+			}
+			reportInfo := gosec.NewReportInfo(plainIssue, &gosec.Metrics{}, map[string][]gosec.Error{}).WithVersion("v2.22.0")
+
+			sarifReport, err := sarif.GenerateReport([]string{}, reportInfo)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(validateSarifSchema(sarifReport)).To(Succeed())
+			Expect(sarifReport.Runs[0].Results[0].Locations).To(HaveLen(1))
+			Expect(sarifReport.Runs[0].Results[0].CodeFlows).To(BeNil())
+
+			buf := new(bytes.Buffer)
+			err = sarif.WriteReport(buf, reportInfo, []string{})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(buf.String()).NotTo(ContainSubstring("codeFlows"))
+		})
 	})
 })
@@ -0,0 +1,431 @@
+package taint
+
+import (
+	"go/constant"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/ssa"
+)
+
+// isTaintedAt checks if v is tainted where it is used in block. A value that
+// has been validated against a fixed allowlist or by a configured guard on
+// every path to block is treated as sanitized there, even if it originates
+// from a source.
+func (a *Analyzer) isTaintedAt(v ssa.Value, block *ssa.BasicBlock, fn *ssa.Function, visited map[ssa.Value]bool, depth int) bool {
+	if a.isAllowlistedAt(v, block) {
+		return false
+	}
+	return a.isTainted(v, fn, visited, depth)
+}
+
+// isAllowlistedAt reports whether block can only be reached after v was
+// validated against a fixed set of values, for example:
+//
+//	if allowed[col] { ... }            // map membership
+//	if _, ok := allowed[col]; ok { ... }
+//	switch col { case "name", "date": ... }
+//	if col != "name" { return }
+//
+// or passed one of the rule's guards, such as a prefix check of a cleaned path.
+// Every incoming edge must either be the validating branch of such a check or
+// come from a block that is itself only reachable after the check.
+func (a *Analyzer) isAllowlistedAt(v ssa.Value, block *ssa.BasicBlock) bool {
+	if v == nil || block == nil {
+		return false
+	}
+	if _, ok := v.(*ssa.Const); ok {
+		return false
+	}
+	// Look through wrappers such as the interface conversion of fmt arguments
+	for {
+		switch w := v.(type) {
+		case *ssa.MakeInterface:
+			v = w.X
+			continue
+		case *ssa.ChangeType:
+			v = w.X
+			continue
+		}
+		break
+	}
+	return a.isBlockGuarded(v, block, make(map[*ssa.BasicBlock]bool))
+}
+
+// isBlockGuarded implements isAllowlistedAt. Blocks on a cycle back to a block
+// being checked are assumed guarded; the other incoming edges decide.
+func (a *Analyzer) isBlockGuarded(v ssa.Value, block *ssa.BasicBlock, inProgress map[*ssa.BasicBlock]bool) bool {
+	if guarded, seen := inProgress[block]; seen {
+		return guarded
+	}
+	if len(block.Preds) == 0 {
+		return false
+	}
+	inProgress[block] = true
+
+	for _, pred := range block.Preds {
+		if a.isValidatingEdge(v, pred, block) {
+			continue
+		}
+		if !a.isBlockGuarded(v, pred, inProgress) {
+			inProgress[block] = false
+			return false
+		}
+	}
+	return true
+}
+
+// isValidatingEdge reports whether the edge from pred to succ is the branch
+// of a condition that validates v.
+func (a *Analyzer) isValidatingEdge(v ssa.Value, pred, succ *ssa.BasicBlock) bool {
+	if len(pred.Instrs) == 0 || len(pred.Succs) != 2 || pred.Succs[0] == pred.Succs[1] {
+		return false
+	}
+	ifInstr, ok := pred.Instrs[len(pred.Instrs)-1].(*ssa.If)
+	if !ok {
+		return false
+	}
+	onTrue := pred.Succs[0] == succ
+	if a.validatesValue(ifInstr.Cond, v, onTrue) || a.validatesKeyOf(ifInstr.Cond, v, onTrue) {
+		return true
+	}
+	return a.guardValidates(ifInstr.Cond, v, onTrue)
+}
+
+// guardValidates reports whether cond is a call to one of the configured
+// guards on v whose validating branch is the true branch when onTrue is set,
+// or the false branch otherwise.
+func (a *Analyzer) guardValidates(cond, v ssa.Value, onTrue bool) bool {
+	if a.config == nil || len(a.config.Guards) == 0 {
+		return false
+	}
+	call, ok := cond.(*ssa.Call)
+	if !ok || len(call.Call.Args) == 0 || call.Call.Args[0] != v {
+		return false
+	}
+	callee := call.Call.StaticCallee()
+	if callee == nil {
+		return false
+	}
+	for _, guard := range a.config.Guards {
+		if guard.Negated == onTrue || !isPackageFunc(callee, guard.Package, guard.Method) {
+			continue
+		}
+		if guard.Arg != "" && !isStringConst(call.Call.Args, 1, guard.Arg) {
+			continue
+		}
+		if len(guard.Normalizers) > 0 && !isNormalized(v, guard.Normalizers) {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// isNormalized reports whether v is the result of a call to one of normalizers.
+func isNormalized(v ssa.Value, normalizers []Sanitizer) bool {
+	call, ok := v.(*ssa.Call)
+	if !ok {
+		return false
+	}
+	callee := call.Call.StaticCallee()
+	if callee == nil {
+		return false
+	}
+	for _, n := range normalizers {
+		if n.Receiver == "" && isPackageFunc(callee, n.Package, n.Method) {
+			return true
+		}
+	}
+	return false
+}
+
+// isPackageFunc reports whether fn is the package-level function pkg.name.
+func isPackageFunc(fn *ssa.Function, pkg, name string) bool {
+	if fn.Signature.Recv() != nil || fn.Pkg == nil || fn.Pkg.Pkg == nil {
+		return false
+	}
+	return fn.Pkg.Pkg.Path() == pkg && fn.Name() == name
+}
+
+// isStringConst reports whether args[i] is the string constant want.
+func isStringConst(args []ssa.Value, i int, want string) bool {
+	if i >= len(args) {
+		return false
+	}
+	c, ok := args[i].(*ssa.Const)
+	return ok && c.Value != nil && c.Value.Kind() == constant.String && constant.StringVal(c.Value) == want
+}
+
+// validatesValue reports whether the branch of cond taken when cond equals
+// onTrue is only taken when v is a member of a fixed allowlist.
+func (a *Analyzer) validatesValue(cond, v ssa.Value, onTrue bool) bool {
+	key := a.allowlistedValue(cond, onTrue)
+	return key != nil && key == v
+}
+
+// allowlistedValue returns the value that the branch of cond taken when cond
+// equals onTrue checks against a fixed allowlist: on the true branch, the key
+// of a boolean lookup in a fixed map, the key of a lookup in a fixed map whose
+// ok result is cond, or a value compared with == to a constant; on the false
+// branch, a value compared with != to a constant. It returns nil for any other
+// condition.
+func (a *Analyzer) allowlistedValue(cond ssa.Value, onTrue bool) ssa.Value {
+	switch c := cond.(type) {
+	case *ssa.Lookup:
+		// allowed[v] on a map[string]bool
+		if onTrue && !c.CommaOk && a.isAllowlistLookup(c) {
+			return c.Index
+		}
+	case *ssa.Extract:
+		// _, ok := allowed[v]
+		if lookup, ok := c.Tuple.(*ssa.Lookup); ok && onTrue && c.Index == 1 && a.isAllowlistLookup(lookup) {
+			return lookup.Index
+		}
+	case *ssa.BinOp:
+		// v == "name" on the true branch, v != "name" on the false branch
+		if (c.Op != token.EQL || !onTrue) && (c.Op != token.NEQ || onTrue) {
+			return nil
+		}
+		if _, ok := c.Y.(*ssa.Const); ok {
+			return c.X
+		}
+		if _, ok := c.X.(*ssa.Const); ok {
+			return c.Y
+		}
+	}
+	return nil
+}
+
+// validatesKeyOf reports whether the branch of cond taken when cond equals
+// onTrue checks one of the rule's allowlist keys of v, such as the host of the
+// URL that v is, is formatted from, or is parsed into.
+func (a *Analyzer) validatesKeyOf(cond, v ssa.Value, onTrue bool) bool {
+	if a.config == nil || len(a.config.AllowlistKeys) == 0 {
+		return false
+	}
+	key := a.allowlistedValue(cond, onTrue)
+	if key == nil {
+		return false
+	}
+	roots := []ssa.Value{v}
+	if call, ok := v.(*ssa.Call); ok {
+		if callee := call.Call.StaticCallee(); callee != nil && callee.Signature.Recv() != nil {
+			roots = append(roots, call.Call.Args[0])
+		}
+	}
+	roots = append(roots, parsedFrom(v)...)
+	for _, root := range roots {
+		for _, ak := range a.config.AllowlistKeys {
+			if isNamedType(root.Type(), ak.Package, ak.Type) && isKeyRead(key, root, ak.Field) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// parsedFrom returns the results of the calls that take v as their first
+// argument, such as u in u, err := url.Parse(v). A key checked on such a
+// result describes v itself.
+func parsedFrom(v ssa.Value) []ssa.Value {
+	refs := v.Referrers()
+	if refs == nil {
+		return nil
+	}
+	var results []ssa.Value
+	for _, ref := range *refs {
+		call, ok := ref.(*ssa.Call)
+		if !ok || len(call.Call.Args) == 0 || call.Call.Args[0] != v {
+			continue
+		}
+		if _, ok := call.Type().(*types.Tuple); !ok {
+			results = append(results, call)
+			continue
+		}
+		for _, callRef := range *call.Referrers() {
+			if extract, ok := callRef.(*ssa.Extract); ok && extract.Index == 0 {
+				results = append(results, extract)
+			}
+		}
+	}
+	return results
+}
+
+// isKeyRead reports whether key reads the named field of root, or is the
+// result of calling the named method on root.
+func isKeyRead(key, root ssa.Value, name string) bool {
+	switch k := key.(type) {
+	case *ssa.UnOp:
+		if k.Op != token.MUL {
+			return false
+		}
+		fa, ok := k.X.(*ssa.FieldAddr)
+		if !ok || fa.X != root {
+			return false
+		}
+		ptr, ok := fa.X.Type().Underlying().(*types.Pointer)
+		if !ok {
+			return false
+		}
+		st, ok := ptr.Elem().Underlying().(*types.Struct)
+		return ok && fa.Field < st.NumFields() && st.Field(fa.Field).Name() == name
+	case *ssa.Call:
+		callee := k.Call.StaticCallee()
+		return callee != nil && callee.Signature.Recv() != nil && callee.Name() == name &&
+			len(k.Call.Args) > 0 && k.Call.Args[0] == root
+	}
+	return false
+}
+
+// isNamedType reports whether t, or the type t points to, is pkg.name.
+func isNamedType(t types.Type, pkg, name string) bool {
+	if ptr, ok := t.Underlying().(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	named, ok := t.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return false
+	}
+	return named.Obj().Pkg().Path() == pkg && named.Obj().Name() == name
+}
+
+// isAllowlistLookup reports whether lookup indexes a fixed map, whose keys
+// cannot come from the checked input: a package-level variable that only the
+// package initializers fill with constant keys, or a map made in place whose
+// keys are all constants and that is only read afterwards.
+func (a *Analyzer) isAllowlistLookup(lookup *ssa.Lookup) bool {
+	if _, ok := lookup.X.Type().Underlying().(*types.Map); !ok {
+		return false
+	}
+	switch m := lookup.X.(type) {
+	case *ssa.UnOp:
+		global, ok := m.X.(*ssa.Global)
+		return ok && m.Op == token.MUL && a.fixedMaps[global]
+	case *ssa.MakeMap:
+		return hasConstantKeys(m, true)
+	}
+	return false
+}
+
+// hasConstantKeys reports whether every key stored in m is a constant and m
+// is only read otherwise, so that it never escapes to code adding other keys.
+// Keys may only be stored when updates is set. m may be stored in a
+// package-level variable by a package initializer, which indexFixedMaps
+// checks in turn.
+func hasConstantKeys(m ssa.Value, updates bool) bool {
+	refs := m.Referrers()
+	if refs == nil {
+		return false
+	}
+	for _, ref := range *refs {
+		switch r := ref.(type) {
+		case *ssa.MapUpdate:
+			if _, ok := r.Key.(*ssa.Const); !ok || !updates || r.Map != m {
+				return false
+			}
+		case *ssa.Lookup:
+			if r.Index == m {
+				return false
+			}
+		case *ssa.Store:
+			if _, ok := r.Addr.(*ssa.Global); !ok || r.Val != m || !isPackageInit(r.Parent()) {
+				return false
+			}
+		case *ssa.Range, *ssa.DebugRef:
+		case *ssa.Call:
+			// len(m) and delete(m, k) do not add keys
+			builtin, ok := r.Call.Value.(*ssa.Builtin)
+			if !ok || (builtin.Name() != "len" && builtin.Name() != "delete") {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// indexFixedMaps records the package-level maps of the packages of the given
+// functions that are fixed allowlists: only the package initializers store a
+// map in them or add keys to them, always constant ones, and their address
+// never escapes. A map of another package is never fixed, since the code
+// filling it is not analyzed.
+func indexFixedMaps(funcs []*ssa.Function) map[*ssa.Global]bool {
+	fixed := make(map[*ssa.Global]bool)
+	seen := make(map[*ssa.Function]bool)
+	var all []*ssa.Function
+	add := func(fn *ssa.Function) {
+		if fn != nil && !seen[fn] {
+			seen[fn] = true
+			all = append(all, fn)
+		}
+	}
+	for _, fn := range funcs {
+		add(fn)
+		if fn.Pkg == nil {
+			continue
+		}
+		// The synthetic initializer holds the package-level var declarations.
+		add(fn.Pkg.Func("init"))
+		for _, member := range fn.Pkg.Members {
+			if global, ok := member.(*ssa.Global); ok {
+				if _, isMap := global.Type().(*types.Pointer).Elem().Underlying().(*types.Map); isMap {
+					if _, indexed := fixed[global]; !indexed {
+						fixed[global] = true
+					}
+				}
+			}
+		}
+	}
+
+	var operands []*ssa.Value
+	for _, fn := range all {
+		for _, block := range fn.Blocks {
+			for _, instr := range block.Instrs {
+				operands = instr.Operands(operands[:0])
+				for i, op := range operands {
+					global, ok := (*op).(*ssa.Global)
+					if !ok || !fixed[global] {
+						continue
+					}
+					if !fixedMapUse(instr, i, fn) {
+						fixed[global] = false
+					}
+				}
+			}
+		}
+	}
+	return fixed
+}
+
+// fixedMapUse reports whether instr, which uses a package-level map as its
+// operand at index i, keeps the map fixed: it is a load of the map that is
+// only read afterwards, or that only fn adds constant keys to when fn is a
+// package initializer, or a store of a map with constant keys made there.
+func fixedMapUse(instr ssa.Instruction, i int, fn *ssa.Function) bool {
+	switch instr := instr.(type) {
+	case *ssa.UnOp:
+		return instr.Op == token.MUL && hasConstantKeys(instr, isPackageInit(fn))
+	case *ssa.Store:
+		// Operands of a Store are its address, then its value.
+		if i != 0 || !isPackageInit(fn) {
+			return false
+		}
+		m, ok := instr.Val.(*ssa.MakeMap)
+		return ok && hasConstantKeys(m, true)
+	case *ssa.DebugRef:
+		return true
+	}
+	return false
+}
+
+// isPackageInit reports whether fn is the initializer of its package or one
+// of the init functions declared in it.
+func isPackageInit(fn *ssa.Function) bool {
+	if fn == nil || fn.Parent() != nil || fn.Signature.Recv() != nil {
+		return false
+	}
+	return fn.Name() == "init" || strings.HasPrefix(fn.Name(), "init#")
+}
@@ -2,12 +2,15 @@ package taint
 
 import (
 	"fmt"
+	"go/ast"
 	"go/token"
+	"log"
 	"os"
 	"strconv"
 
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/passes/buildssa"
+	"golang.org/x/tools/go/callgraph"
 	"golang.org/x/tools/go/ssa"
 
 	"github.com/securego/gosec/v2/internal/ssautil"
@@ -42,64 +45,140 @@ func makeAnalyzerRunner(rule *RuleInfo, config *Config) func(*analysis.Pass) (in
 			return nil, fmt.Errorf("taint analysis %s: failed to get SSA result: %w", rule.ID, err)
 		}
 
-		// Collect source functions (filter out nil)
-		var srcFuncs []*ssa.Function
-		for _, fn := range ssaResult.SSA.SrcFuncs {
-			if fn != nil {
-				srcFuncs = append(srcFuncs, fn)
-			}
+		var callGraph *callgraph.Graph
+		if ssaResult.Shared != nil {
+			callGraph = ssaResult.Shared.CallGraph()
 		}
+		issues, err := runRule(rule, config, ssaResult.Config, ssaResult.SSA.SrcFuncs, callGraph, pass, ssaResult.Logger)
+		if len(issues) > 0 {
+			return issues, err
+		}
+		return nil, err
+	}
+}
+
+// Run runs the taint analysis of a rule on srcFuncs, the source functions
+// of an SSA program already built by the caller, and returns the issues
+// found. globalConfig is the gosec configuration holding the rule settings,
+// if any. callGraph may be shared between the rules run on the same
+// program; when nil, the analysis builds its own. The issues have no
+// autofix or subcategory, as these need the syntax and type information
+// of an analysis pass. Functions skipped for exceeding the configured
+// function timeout are not logged.
+func Run(rule *RuleInfo, config *Config, globalConfig map[string]interface{}, srcFuncs []*ssa.Function, callGraph *callgraph.Graph) ([]*issue.Issue, error) {
+	return runRule(rule, config, globalConfig, srcFuncs, callGraph, nil, nil)
+}
 
-		if len(srcFuncs) == 0 {
-			return nil, nil // No functions to analyze - this is OK
+// runRule runs the taint analysis of a rule on srcFuncs and converts its
+// results into issues. The issues are fixed, classified and reported to the
+// pass when there is one. Functions skipped for exceeding the configured
+// function timeout are logged to logger, if not nil.
+func runRule(rule *RuleInfo, config *Config, globalConfig map[string]interface{}, funcs []*ssa.Function,
+	callGraph *callgraph.Graph, pass *analysis.Pass, logger *log.Logger,
+) ([]*issue.Issue, error) {
+	// Collect source functions (filter out nil)
+	var srcFuncs []*ssa.Function
+	for _, fn := range funcs {
+		if fn != nil {
+			srcFuncs = append(srcFuncs, fn)
 		}
+	}
 
-		// Run taint analysis
-		analyzer := New(config)
-		if ssaResult.Shared != nil {
-			analyzer.SetCallGraph(ssaResult.Shared.CallGraph())
+	if len(srcFuncs) == 0 {
+		return nil, nil // No functions to analyze - this is OK
+	}
+
+	// Merge user-supplied settings from the rule's configuration section
+	ruleConfig := config
+	if settings := ruleSettings(globalConfig, rule.ID); settings != nil {
+		var err error
+		ruleConfig, err = mergeRuleConfig(config, settings)
+		if err != nil {
+			return nil, fmt.Errorf("taint analysis %s: invalid configuration: %w", rule.ID, err)
 		}
-		results := analyzer.Analyze(srcFuncs[0].Prog, srcFuncs)
-
-		// Convert results to gosec issues
-		var issues []*issue.Issue
-		for _, result := range results {
-			// Map severity string to issue.Score
-			var severity issue.Score
-			switch rule.Severity {
-			case "LOW":
-				severity = issue.Low
-			case "MEDIUM":
-				severity = issue.Medium
-			case "HIGH":
-				severity = issue.High
-			case "CRITICAL":
-				severity = issue.High // gosec uses High for critical
-			default:
-				severity = issue.Medium
-			}
+	}
+
+	// Run taint analysis
+	analyzer := New(ruleConfig)
+	if callGraph != nil {
+		analyzer.SetCallGraph(callGraph)
+	}
+	prog := srcFuncs[0].Prog
+	results := analyzer.Analyze(prog, srcFuncs)
+	if err := analyzer.WriteGraphs(); err != nil {
+		return nil, fmt.Errorf("taint analysis %s: failed to write taint graphs: %w", rule.ID, err)
+	}
+	if logger != nil {
+		for _, fn := range analyzer.TimedOut() {
+			logger.Printf("taint analysis %s: skipped %s at %s: analysis exceeded the %s of %s",
+				rule.ID, fn, prog.Fset.Position(fn.Pos()), ConfigFunctionTimeout, ruleConfig.FunctionTimeout)
+		}
+	}
 
-			// Create gosec issue using the standard helper
-			newIssue := newIssue(
-				rule.ID,
-				rule.Description,
-				pass.Fset,
-				result.SinkPos,
-				severity,
-				issue.High, // confidence
-			)
+	// Convert results to gosec issues
+	var issues []*issue.Issue
+	for _, result := range results {
+		// Map severity string to issue.Score
+		var severity issue.Score
+		switch rule.Severity {
+		case "LOW":
+			severity = issue.Low
+		case "MEDIUM":
+			severity = issue.Medium
+		case "HIGH":
+			severity = issue.High
+		case "CRITICAL":
+			severity = issue.High // gosec uses High for critical
+		default:
+			severity = issue.Medium
+		}
 
-			issues = append(issues, newIssue)
+		// A flow through a container tainted as a whole is less certain
+		confidence := issue.High
+		if result.Approximate {
+			confidence = issue.Medium
+		}
 
-			// Report to analysis pass (for use with go vet style tools)
-			pass.Reportf(result.SinkPos, "%s: %s", rule.ID, rule.Description)
+		// Create gosec issue using the standard helper
+		what := rule.Description
+		newIssue := newIssue(
+			rule.ID,
+			what,
+			prog.Fset,
+			result.SinkPos,
+			severity,
+			confidence,
+		)
+		newIssue.Flow = newFlow(prog.Fset, result.Flow)
+		newIssue.TaintFlow = issue.NewTaintFlow(newIssue.Flow)
+		if newIssue.TaintFlow != nil {
+			newIssue.TaintFlow.MergedSinks = newFlow(prog.Fset, result.MergedSinks)
 		}
+		newIssue.Diagnostics = newFlow(prog.Fset, result.Approximations)
+		issues = append(issues, newIssue)
 
-		if len(issues) > 0 {
-			return issues, nil
+		if pass == nil {
+			continue
 		}
-		return nil, nil
+		if ruleConfig.Autofix != nil || ruleConfig.Classify != nil {
+			if call := sinkCallExpr(pass, result.SinkPos); call != nil {
+				if ruleConfig.Autofix != nil {
+					newIssue.Autofix = ruleConfig.Autofix(pass, call)
+				}
+				if ruleConfig.Classify != nil {
+					if subcategory, detail := ruleConfig.Classify(pass, call, result.Sink); subcategory != "" {
+						what = rule.Description + ": " + detail
+						newIssue.Subcategory = subcategory
+						newIssue.What = what
+					}
+				}
+			}
+		}
+
+		// Report to analysis pass (for use with go vet style tools)
+		pass.Reportf(result.SinkPos, "%s: %s", rule.ID, what)
 	}
+	return issues, nil
 }
 
 // newIssue creates a new gosec issue
@@ -126,6 +205,47 @@ func newIssue(analyzerID string, desc string, fileSet *token.FileSet,
 	}
 }
 
+// sinkCallExpr returns the call expression whose opening parenthesis is at
+// pos, the position of a sink call instruction.
+func sinkCallExpr(pass *analysis.Pass, pos token.Pos) *ast.CallExpr {
+	for _, file := range pass.Files {
+		if pos < file.Pos() || pos > file.End() {
+			continue
+		}
+		var found *ast.CallExpr
+		ast.Inspect(file, func(n ast.Node) bool {
+			if found != nil || n == nil || pos < n.Pos() || pos > n.End() {
+				return false
+			}
+			if call, ok := n.(*ast.CallExpr); ok && call.Lparen == pos {
+				found = call
+			}
+			return found == nil
+		})
+		return found
+	}
+	return nil
+}
+
+// newFlow converts the steps of a taint flow into issue flow steps
+func newFlow(fileSet *token.FileSet, steps []FlowStep) []issue.FlowStep {
+	var flow []issue.FlowStep
+	for _, step := range steps {
+		pos := fileSet.Position(step.Pos)
+		if !pos.IsValid() {
+			continue
+		}
+		flow = append(flow, issue.FlowStep{
+			File:        pos.Filename,
+			Line:        strconv.Itoa(pos.Line),
+			Col:         strconv.Itoa(pos.Column),
+			Description: step.Description,
+			Kind:        step.Kind,
+		})
+	}
+	return flow
+}
+
 func issueCodeSnippet(fileSet *token.FileSet, pos token.Pos) string {
 	file := fileSet.File(pos)
 	start := (int64)(file.Line(pos))
@@ -540,13 +540,142 @@ func BenchmarkTaintAnalysisManySinkCalls(b *testing.B) {
 		},
 	}
 
-	b.ResetTimer()
 	for b.Loop() {
 		analyzer := New(cfg)
 		analyzer.Analyze(prog, srcFuncs)
 	}
 }
 
+// buildHelperCallsFixture creates an SSA program in which many functions call
+// the same helper with tainted arguments inside a loop. Used by both the
+// function summary test and benchmarks.
+func buildHelperCallsFixture(tb testing.TB) (*ssa.Program, []*ssa.Function) {
+	tb.Helper()
+
+	src := `package p
+
+type DB struct{}
+
+func (d *DB) Query(q string) {}
+
+func input() string { return "" }
+
+func quote(s string) string { return "'" + s + "'" }
+`
+	for i := 0; i < 20; i++ {
+		src += fmt.Sprintf(`
+func caller%d(d *DB, n int) {
+	for i := 0; i < n; i++ {
+		d.Query("SELECT * FROM t WHERE a = " + quote(input()) + " AND b = " + quote(input()))
+		d.Query("SELECT * FROM t WHERE c = " + quote("c"))
+	}
+}
+`, i)
+	}
+
+	fset := token.NewFileSet()
+	parsed, err := parser.ParseFile(fset, "p.go", src, 0)
+	if err != nil {
+		tb.Fatalf("parse: %v", err)
+	}
+	info := &types.Info{
+		Types:      make(map[ast.Expr]types.TypeAndValue),
+		Defs:       make(map[*ast.Ident]types.Object),
+		Uses:       make(map[*ast.Ident]types.Object),
+		Implicits:  make(map[ast.Node]types.Object),
+		Scopes:     make(map[ast.Node]*types.Scope),
+		Selections: make(map[*ast.SelectorExpr]*types.Selection),
+	}
+	pkg, err := (&types.Config{}).Check("p", fset, []*ast.File{parsed}, info)
+	if err != nil {
+		tb.Fatalf("type-check: %v", err)
+	}
+	prog := ssa.NewProgram(fset, ssa.BuilderMode(0))
+	ssaPkg := prog.CreatePackage(pkg, []*ast.File{parsed}, info, true)
+	prog.Build()
+
+	var srcFuncs []*ssa.Function
+	for i := 0; i < 20; i++ {
+		fn := ssaPkg.Func(fmt.Sprintf("caller%d", i))
+		if fn == nil {
+			tb.Fatalf("SSA function caller%d not found", i)
+		}
+		srcFuncs = append(srcFuncs, fn)
+	}
+	return prog, srcFuncs
+}
+
+var helperCallsConfig = &Config{
+	Sources: []Source{{Package: "p", Name: "input", IsFunc: true}},
+	Sinks:   []Sink{{Package: "p", Receiver: "DB", Method: "Query", Pointer: true, CheckArgs: []int{1}}},
+}
+
+// analyzeWithSummaries runs the steps of Analyze serially with the given
+// summary cache, which may be nil to compute every summary at each call site.
+func analyzeWithSummaries(prog *ssa.Program, srcFuncs []*ssa.Function, summaries *summaryCache) []Result {
+	analyzer := New(helperCallsConfig)
+	analyzer.prog = prog
+	analyzer.callGraph = cha.CallGraph(prog)
+	analyzer.paramTaintCache = newTaintCache()
+	analyzer.summaries = summaries
+	analyzer.globalStores = indexGlobalStores(srcFuncs)
+
+	var results []Result
+	for _, fn := range srcFuncs {
+		results = append(results, analyzer.analyzeFunctionSinks(fn)...)
+	}
+	return results
+}
+
+func TestFunctionSummariesDoNotChangeResults(t *testing.T) {
+	t.Parallel()
+
+	prog, srcFuncs := buildHelperCallsFixture(t)
+
+	summaries := newSummaryCache()
+	withSummaries := analyzeWithSummaries(prog, srcFuncs, summaries)
+	withoutSummaries := analyzeWithSummaries(prog, srcFuncs, nil)
+
+	// Only the query with quote(input()) is tainted in each caller
+	if len(withSummaries) != len(srcFuncs) {
+		t.Fatalf("expected %d results, got %d", len(srcFuncs), len(withSummaries))
+	}
+	if len(withSummaries) != len(withoutSummaries) {
+		t.Fatalf("summaries changed the number of results: %d != %d", len(withSummaries), len(withoutSummaries))
+	}
+	for i := range withSummaries {
+		if withSummaries[i].SinkPos != withoutSummaries[i].SinkPos {
+			t.Fatalf("result %d differs: %v != %v", i, withSummaries[i].SinkPos, withoutSummaries[i].SinkPos)
+		}
+	}
+
+	// The 40 tainted call sites of quote share a single summary
+	if len(summaries.flows) != 1 {
+		t.Fatalf("expected 1 summary, got %d", len(summaries.flows))
+	}
+	for key, flows := range summaries.flows {
+		if key.fn.Name() != "quote" || !flows {
+			t.Fatalf("unexpected summary %s %s: %v", key.fn, key.params, flows)
+		}
+	}
+}
+
+func BenchmarkTaintAnalysisHelperCallsWithSummaries(b *testing.B) {
+	prog, srcFuncs := buildHelperCallsFixture(b)
+
+	for b.Loop() {
+		analyzeWithSummaries(prog, srcFuncs, newSummaryCache())
+	}
+}
+
+func BenchmarkTaintAnalysisHelperCallsWithoutSummaries(b *testing.B) {
+	prog, srcFuncs := buildHelperCallsFixture(b)
+
+	for b.Loop() {
+		analyzeWithSummaries(prog, srcFuncs, nil)
+	}
+}
+
 func TestResolveOriginalTypeMakeInterface(t *testing.T) {
 	t.Parallel()
 	// Build a minimal, self-contained SSA program (no external imports) that
@@ -1006,7 +1135,7 @@ func twoParams(r *http.Request, s string) {}
 	})
 	// Do NOT call Analyze — callGraph stays nil.
 	// Initialize paramTaintCache so the cache-store branch is exercised.
-	analyzer.paramTaintCache = make(map[paramKey]bool)
+	analyzer.paramTaintCache = newTaintCache()
 
 	// Source-type param → auto-taint (and caches result).
 	visited := make(map[ssa.Value]bool)
@@ -1015,7 +1144,7 @@ func twoParams(r *http.Request, s string) {}
 	}
 
 	// Verify cache was populated.
-	if !analyzer.paramTaintCache[paramKey{fn: fn, paramIdx: 0}] {
+	if !analyzer.paramTaintCache.has(paramKey{fn: fn, paramIdx: 0}) {
 		t.Fatal("expected cache to contain taint result for param 0")
 	}
 
@@ -1117,7 +1246,7 @@ func lonely(r *http.Request) {}
 	})
 	// Manually set up call graph + cache (same as Analyze does internally).
 	analyzer.callGraph = cha.CallGraph(prog)
-	analyzer.paramTaintCache = make(map[paramKey]bool)
+	analyzer.paramTaintCache = newTaintCache()
 	analyzer.prog = prog
 
 	// First call: entry point (no callers) + source type → auto-taint + cache store.
@@ -1125,7 +1254,7 @@ func lonely(r *http.Request) {}
 	if !analyzer.isParameterTainted(fn.Params[0], fn, visited, 0) {
 		t.Fatal("expected entry-point source-type param to be tainted")
 	}
-	if !analyzer.paramTaintCache[paramKey{fn: fn, paramIdx: 0}] {
+	if !analyzer.paramTaintCache.has(paramKey{fn: fn, paramIdx: 0}) {
 		t.Fatal("expected cache to be populated")
 	}
 
@@ -1135,3 +1264,38 @@ func lonely(r *http.Request) {}
 		t.Fatal("expected cache hit to return true")
 	}
 }
+
+func TestDedupeSourcesKeepsEarliestSink(t *testing.T) {
+	t.Parallel()
+
+	param := FlowStep{Pos: 1, Description: "parameter r", Kind: issue.FlowKindParameter}
+	name := FlowStep{Pos: 2, Description: "t0 = FormValue(r, \"name\")", Kind: issue.FlowKindCall}
+	other := FlowStep{Pos: 3, Description: "t1 = FormValue(r, \"other\")", Kind: issue.FlowKindCall}
+	sink := func(pos token.Pos) FlowStep {
+		return FlowStep{Pos: pos, Description: "Exec", Kind: issue.FlowKindCall}
+	}
+	results := []Result{
+		{SinkPos: 20, Flow: []FlowStep{param, name, sink(20)}},
+		{SinkPos: 10, Flow: []FlowStep{param, name, sink(10)}},
+		{SinkPos: 30, Flow: []FlowStep{param, other, sink(30)}},
+		{SinkPos: 15, Flow: []FlowStep{param, name, sink(15)}},
+		{SinkPos: 40},
+	}
+
+	deduped := dedupeSources(results)
+	if len(deduped) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(deduped))
+	}
+	if deduped[0].SinkPos != 10 {
+		t.Fatalf("expected the earliest sink of the name source, got %d", deduped[0].SinkPos)
+	}
+	if got := deduped[0].MergedSinks; len(got) != 2 || got[0].Pos != 15 || got[1].Pos != 20 {
+		t.Fatalf("expected the merged sinks 15 and 20, got %v", got)
+	}
+	if deduped[1].SinkPos != 30 || len(deduped[1].MergedSinks) != 0 {
+		t.Fatalf("expected the other source to be kept alone, got %+v", deduped[1])
+	}
+	if deduped[2].SinkPos != 40 {
+		t.Fatalf("expected the result without a flow to be kept, got %d", deduped[2].SinkPos)
+	}
+}
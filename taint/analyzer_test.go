@@ -8,6 +8,7 @@ import (
 	"golang.org/x/tools/go/analysis"
 
 	"github.com/securego/gosec/v2/taint"
+	"github.com/securego/gosec/v2/testutils"
 )
 
 var _ = Describe("Taint Analyzer Integration", func() {
@@ -481,4 +482,55 @@ var _ = Describe("Taint Analyzer Integration", func() {
 			Expect(pass.Fset).NotTo(BeNil())
 		})
 	})
+
+	Context("Registering rules", func() {
+		// envSample sets an environment variable from a query parameter, once
+		// directly and once escaped.
+		envSample := testutils.CodeSample{Code: []string{`
+package main
+
+import (
+	"net/http"
+	"net/url"
+	"os"
+)
+
+func handler(r *http.Request) {
+	name := r.URL.Query().Get("name")
+	_ = os.Setenv("NAME", name)
+	_ = os.Setenv("ESCAPED_NAME", url.QueryEscape(name))
+}
+`}}
+
+		It("should report flows to a registered sink", func() {
+			program, err := buildSampleProgram(envSample)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			analyzer := taint.New(&taint.Config{})
+			analyzer.AddSource(taint.Source{Package: "net/http", Name: "Request", Pointer: true})
+			analyzer.AddSink(taint.Sink{Package: "os", Method: "Setenv", CheckArgs: []int{1}})
+			analyzer.AddSanitizer(taint.Sanitizer{Package: "net/url", Method: "QueryEscape"})
+
+			results := analyzer.Analyze(program.prog, program.funcs)
+			Expect(results).Should(HaveLen(1))
+			Expect(results[0].Sink.Method).Should(Equal("Setenv"))
+			Expect(program.prog.Fset.Position(results[0].SinkPos).Line).Should(Equal(12))
+			Expect(results[0].Flow).ShouldNot(BeEmpty())
+		})
+
+		It("should add registered rules to the configured ones", func() {
+			program, err := buildSampleProgram(envSample)
+			Expect(err).ShouldNot(HaveOccurred())
+			config := &taint.Config{
+				Sources: []taint.Source{{Package: "net/http", Name: "Request", Pointer: true}},
+				Sinks:   []taint.Sink{{Package: "os", Method: "Setenv", CheckArgs: []int{1}}},
+			}
+			Expect(taint.New(config).Analyze(program.prog, program.funcs)).Should(HaveLen(2))
+
+			analyzer := taint.New(config)
+			analyzer.AddSanitizer(taint.Sanitizer{Package: "net/url", Method: "QueryEscape"})
+			Expect(analyzer.Analyze(program.prog, program.funcs)).Should(HaveLen(1))
+			Expect(config.Sanitizers).Should(BeEmpty())
+		})
+	})
 })
@@ -0,0 +1,519 @@
+package taint
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Keys of the per-rule section in the gosec configuration. A taint rule reads
+// its section by rule ID, for example:
+//
+//	{
+//	  "G701": {
+//	    "sources": ["mycompany/web.(*Ctx).Param"],
+//	    "sanitizers": ["mycompany/dbutil.SafeIdent"],
+//	    "sinks": [{"signature": "mycompany/store.Run", "args": [0]}]
+//	  }
+//	}
+const (
+	// ConfigSources lists extra function or method signatures whose return values are tainted
+	ConfigSources = "sources"
+	// ConfigSanitizers lists extra function or method signatures whose return values are safe
+	ConfigSanitizers = "sanitizers"
+	// ConfigSinks lists extra sinks, either as plain signatures (all arguments
+	// are checked) or as objects with a signature and the checked argument indexes
+	ConfigSinks = "sinks"
+	// ConfigGraphDir is a directory to write the taint graph of every function
+	// with sink calls to, as Graphviz DOT files. It is meant for debugging and
+	// does not change the findings.
+	ConfigGraphDir = "graph_dir"
+	// ConfigMaxCallDepth caps the number of calls the analysis follows from a
+	// sink, into callers of a function or into the helpers it calls. Data is
+	// assumed tainted where the cap stops the analysis.
+	ConfigMaxCallDepth = "max_call_depth"
+	// ConfigProtoPackages lists package path prefixes whose types are
+	// protobuf messages. Setting it makes the Get methods of the messages
+	// that return a string sources.
+	ConfigProtoPackages = "proto_packages"
+	// ConfigExcludePaths lists path globs of files whose sinks are not
+	// reported. The functions in them are still followed when tracing taint
+	// from the other files.
+	ConfigExcludePaths = "exclude_paths"
+	// ConfigFunctionTimeout caps the time spent checking the sinks of one
+	// function, as a duration such as "5s". A function taking longer, such as
+	// a huge generated one, is skipped and logged.
+	ConfigFunctionTimeout = "function_timeout"
+	// ConfigDedupeSources reports a single finding per source reaching several
+	// sinks, at its earliest sink. The other sinks are listed in the finding.
+	ConfigDedupeSources = "dedupe_sources"
+)
+
+// Keys of a sink object in the ConfigSinks list.
+const (
+	sinkSignature = "signature"
+	// sinkArgs are zero-based indexes of the declared parameters, not counting the receiver
+	sinkArgs = "args"
+)
+
+// funcSignature is a parsed fully-qualified function or method signature.
+type funcSignature struct {
+	pkg      string
+	receiver string
+	name     string
+	pointer  bool
+	// anyRecv is set when the signature did not say whether the receiver is a pointer
+	anyRecv bool
+}
+
+// parseFuncSignature parses a fully-qualified function or method signature.
+// Supported forms are:
+//
+//	"pkg/path.Func"
+//	"pkg/path.Type.Method"       (pointer and value receivers)
+//	"pkg/path.(*Type).Method"
+//	"(*pkg/path.Type).Method"    (as printed by go/types)
+//
+// The last element of the package path may end in a major version, as in
+// "gopkg.in/yaml.v3.Unmarshal".
+func parseFuncSignature(sig string) (funcSignature, error) {
+	sig = strings.TrimSpace(sig)
+	if sig == "" {
+		return funcSignature{}, fmt.Errorf("empty signature")
+	}
+
+	// "(*pkg/path.Type).Method" or "(pkg/path.Type).Method"
+	if strings.HasPrefix(sig, "(") {
+		closing := strings.Index(sig, ")")
+		if closing < 0 || closing+2 > len(sig) || sig[closing+1] != '.' {
+			return funcSignature{}, fmt.Errorf("malformed signature %q", sig)
+		}
+		recv := sig[1:closing]
+		pointer := strings.HasPrefix(recv, "*")
+		recv = strings.TrimPrefix(recv, "*")
+		dot := strings.LastIndex(recv, ".")
+		if dot <= 0 || dot == len(recv)-1 {
+			return funcSignature{}, fmt.Errorf("malformed receiver in signature %q", sig)
+		}
+		return funcSignature{
+			pkg:      recv[:dot],
+			receiver: recv[dot+1:],
+			name:     sig[closing+2:],
+			pointer:  pointer,
+		}, nil
+	}
+
+	// The package path may contain dots (e.g., "github.com/..."), so only
+	// the part after the last slash is split into its components.
+	prefix := ""
+	rest := sig
+	if slash := strings.LastIndex(sig, "/"); slash >= 0 {
+		prefix = sig[:slash+1]
+		rest = sig[slash+1:]
+	}
+
+	// "pkg/path.(*Type).Method"
+	if open := strings.Index(rest, ".("); open >= 0 {
+		closing := strings.Index(rest, ")")
+		if closing < open || closing+2 > len(rest) || rest[closing+1] != '.' {
+			return funcSignature{}, fmt.Errorf("malformed signature %q", sig)
+		}
+		recv := rest[open+2 : closing]
+		pointer := strings.HasPrefix(recv, "*")
+		return funcSignature{
+			pkg:      prefix + rest[:open],
+			receiver: strings.TrimPrefix(recv, "*"),
+			name:     rest[closing+2:],
+			pointer:  pointer,
+		}, nil
+	}
+
+	for _, part := range strings.Split(rest, ".") {
+		if part == "" {
+			return funcSignature{}, fmt.Errorf("malformed signature %q", sig)
+		}
+	}
+	// The name follows the last dot. Before it come the last path element
+	// and, for "pkg/path.Type.Method", the receiver type. The path element
+	// may itself hold a dot before a major version, as in "gopkg.in/yaml.v3".
+	elem, name, ok := cutLast(rest)
+	if !ok {
+		return funcSignature{}, fmt.Errorf("malformed signature %q", sig)
+	}
+	receiver := ""
+	if head, tail, ok := cutLast(elem); ok && !isMajorVersion(tail) {
+		elem, receiver = head, tail
+	}
+	if _, tail, ok := cutLast(elem); ok && !isMajorVersion(tail) {
+		return funcSignature{}, fmt.Errorf("malformed signature %q", sig)
+	}
+	if receiver == "" {
+		return funcSignature{pkg: prefix + elem, name: name}, nil
+	}
+	return funcSignature{pkg: prefix + elem, receiver: receiver, name: name, anyRecv: true}, nil
+}
+
+// cutLast slices s around the last dot.
+func cutLast(s string) (before, after string, found bool) {
+	dot := strings.LastIndex(s, ".")
+	if dot < 0 {
+		return s, "", false
+	}
+	return s[:dot], s[dot+1:], true
+}
+
+// isMajorVersion reports whether s is a major version suffix of an import
+// path element, such as "v3" in "gopkg.in/yaml.v3".
+func isMajorVersion(s string) bool {
+	if len(s) < 2 || s[0] != 'v' {
+		return false
+	}
+	for _, r := range s[1:] {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// sources converts the signature into function sources.
+func (s funcSignature) sources() []Source {
+	src := Source{Package: s.pkg, Name: s.name, Receiver: s.receiver, Pointer: s.pointer, IsFunc: true}
+	if !s.anyRecv {
+		return []Source{src}
+	}
+	ptr := src
+	ptr.Pointer = true
+	return []Source{src, ptr}
+}
+
+// sanitizers converts the signature into sanitizers.
+func (s funcSignature) sanitizers() []Sanitizer {
+	san := Sanitizer{Package: s.pkg, Receiver: s.receiver, Method: s.name, Pointer: s.pointer}
+	if !s.anyRecv {
+		return []Sanitizer{san}
+	}
+	ptr := san
+	ptr.Pointer = true
+	return []Sanitizer{san, ptr}
+}
+
+// sinks converts the signature into sinks checking the given declared
+// parameters. An empty list checks all arguments.
+func (s funcSignature) sinks(params []int) []Sink {
+	var checkArgs []int
+	for _, p := range params {
+		// For methods, Args[0] is the receiver
+		if s.receiver != "" {
+			p++
+		}
+		checkArgs = append(checkArgs, p)
+	}
+	sink := Sink{Package: s.pkg, Receiver: s.receiver, Method: s.name, Pointer: s.pointer, CheckArgs: checkArgs}
+	if !s.anyRecv {
+		return []Sink{sink}
+	}
+	ptr := sink
+	ptr.Pointer = true
+	return []Sink{sink, ptr}
+}
+
+// sinkList reads the list of sinks from a configuration value.
+func sinkList(value interface{}) ([]Sink, error) {
+	items, ok := value.([]interface{})
+	if !ok {
+		// Plain signatures provided programmatically
+		sigs, err := signatureList(value)
+		if err != nil {
+			return nil, err
+		}
+		var sinks []Sink
+		for _, sig := range sigs {
+			sinks = append(sinks, sig.sinks(nil)...)
+		}
+		return sinks, nil
+	}
+
+	var sinks []Sink
+	for _, item := range items {
+		var sig string
+		var params []int
+		switch v := item.(type) {
+		case string:
+			sig = v
+		case map[string]interface{}:
+			sig, ok = v[sinkSignature].(string)
+			if !ok {
+				return nil, fmt.Errorf("sink is missing a %q string", sinkSignature)
+			}
+			if raw, ok := v[sinkArgs]; ok {
+				var err error
+				params, err = intList(raw)
+				if err != nil {
+					return nil, fmt.Errorf("sink %s: %s: %w", sig, sinkArgs, err)
+				}
+			}
+		default:
+			return nil, fmt.Errorf("expected a signature or a sink object, got %T", item)
+		}
+		parsed, err := parseFuncSignature(sig)
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, parsed.sinks(params)...)
+	}
+	return sinks, nil
+}
+
+// intList reads a list of non-negative integers from a configuration value.
+// Numbers loaded from JSON arrive as float64.
+func intList(value interface{}) ([]int, error) {
+	switch v := value.(type) {
+	case []int:
+		for _, n := range v {
+			if n < 0 {
+				return nil, fmt.Errorf("negative index %d", n)
+			}
+		}
+		return v, nil
+	case []interface{}:
+		list := make([]int, 0, len(v))
+		for _, item := range v {
+			var n int
+			switch num := item.(type) {
+			case float64:
+				if num != float64(int(num)) {
+					return nil, fmt.Errorf("expected an integer, got %v", num)
+				}
+				n = int(num)
+			case int:
+				n = num
+			default:
+				return nil, fmt.Errorf("expected an integer, got %T", item)
+			}
+			if n < 0 {
+				return nil, fmt.Errorf("negative index %d", n)
+			}
+			list = append(list, n)
+		}
+		return list, nil
+	default:
+		return nil, fmt.Errorf("expected a list of integers, got %T", value)
+	}
+}
+
+// positiveInt reads a positive integer from a configuration value. Numbers
+// loaded from JSON arrive as float64.
+func positiveInt(value interface{}) (int, error) {
+	var n int
+	switch v := value.(type) {
+	case int:
+		n = v
+	case float64:
+		if v != float64(int(v)) {
+			return 0, fmt.Errorf("expected an integer, got %v", v)
+		}
+		n = int(v)
+	default:
+		return 0, fmt.Errorf("expected an integer, got %T", value)
+	}
+	if n < 1 {
+		return 0, fmt.Errorf("expected a positive integer, got %d", n)
+	}
+	return n, nil
+}
+
+// signatureList reads a list of function signatures from a configuration value.
+func signatureList(value interface{}) ([]funcSignature, error) {
+	sigs, err := stringList(value)
+	if err != nil {
+		return nil, err
+	}
+	parsed := make([]funcSignature, 0, len(sigs))
+	for _, sig := range sigs {
+		p, err := parseFuncSignature(sig)
+		if err != nil {
+			return nil, err
+		}
+		parsed = append(parsed, p)
+	}
+	return parsed, nil
+}
+
+// FunctionKeys parses a list of fully-qualified function or method signatures,
+// written in any of the forms accepted by the taint rule configuration, into
+// the names printed by ssa.Function.String (e.g., "(*pkg/path.Type).Method").
+// It lets other analyzers accept signatures in the same notation.
+func FunctionKeys(value interface{}) (map[string]struct{}, error) {
+	sigs, err := signatureList(value)
+	if err != nil {
+		return nil, err
+	}
+	keys := make(map[string]struct{}, len(sigs))
+	for _, sig := range sigs {
+		for _, san := range sig.sanitizers() {
+			keys[formatSanitizerKey(san)] = struct{}{}
+		}
+	}
+	return keys, nil
+}
+
+// stringList reads a list of strings from a configuration value. Values loaded
+// from JSON arrive as []interface{}, while programmatic configs may use []string.
+func stringList(value interface{}) ([]string, error) {
+	switch v := value.(type) {
+	case []string:
+		return v, nil
+	case []interface{}:
+		list := make([]string, 0, len(v))
+		for _, item := range v {
+			str, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("expected string, got %T", item)
+			}
+			list = append(list, str)
+		}
+		return list, nil
+	default:
+		return nil, fmt.Errorf("expected a list of strings, got %T", value)
+	}
+}
+
+// mergeRuleConfig returns a copy of base extended with the settings from the
+// rule's section of the gosec configuration. The base config is never modified.
+func mergeRuleConfig(base *Config, settings map[string]interface{}) (*Config, error) {
+	merged := &Config{
+		Sources:         slices.Clone(base.Sources),
+		Sinks:           slices.Clone(base.Sinks),
+		Sanitizers:      slices.Clone(base.Sanitizers),
+		Guards:          slices.Clone(base.Guards),
+		AllowlistKeys:   slices.Clone(base.AllowlistKeys),
+		GraphDir:        base.GraphDir,
+		MaxCallDepth:    base.MaxCallDepth,
+		ProtoGetters:    base.ProtoGetters,
+		ProtoPackages:   slices.Clone(base.ProtoPackages),
+		ExcludePaths:    slices.Clone(base.ExcludePaths),
+		FunctionTimeout: base.FunctionTimeout,
+		DedupeSources:   base.DedupeSources,
+		Autofix:         base.Autofix,
+		Classify:        base.Classify,
+	}
+
+	if raw, ok := settings[ConfigSources]; ok {
+		sigs, err := signatureList(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", ConfigSources, err)
+		}
+		for _, sig := range sigs {
+			merged.Sources = append(merged.Sources, sig.sources()...)
+		}
+	}
+
+	if raw, ok := settings[ConfigSinks]; ok {
+		sinks, err := sinkList(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", ConfigSinks, err)
+		}
+		merged.Sinks = append(merged.Sinks, sinks...)
+	}
+
+	if raw, ok := settings[ConfigSanitizers]; ok {
+		sigs, err := signatureList(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", ConfigSanitizers, err)
+		}
+		for _, sig := range sigs {
+			merged.Sanitizers = append(merged.Sanitizers, sig.sanitizers()...)
+		}
+	}
+
+	if raw, ok := settings[ConfigGraphDir]; ok {
+		dir, ok := raw.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s: expected a string, got %T", ConfigGraphDir, raw)
+		}
+		merged.GraphDir = dir
+	}
+
+	if raw, ok := settings[ConfigMaxCallDepth]; ok {
+		depth, err := positiveInt(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", ConfigMaxCallDepth, err)
+		}
+		merged.MaxCallDepth = depth
+	}
+
+	if raw, ok := settings[ConfigProtoPackages]; ok {
+		packages, err := stringList(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", ConfigProtoPackages, err)
+		}
+		merged.ProtoPackages = append(merged.ProtoPackages, packages...)
+		merged.ProtoGetters = true
+	}
+
+	if raw, ok := settings[ConfigExcludePaths]; ok {
+		patterns, err := stringList(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", ConfigExcludePaths, err)
+		}
+		for _, pattern := range patterns {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, fmt.Errorf("%s: invalid pattern %q: %w", ConfigExcludePaths, pattern, err)
+			}
+		}
+		merged.ExcludePaths = append(merged.ExcludePaths, patterns...)
+	}
+
+	if raw, ok := settings[ConfigFunctionTimeout]; ok {
+		text, ok := raw.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s: expected a duration string, got %T", ConfigFunctionTimeout, raw)
+		}
+		timeout, err := time.ParseDuration(text)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", ConfigFunctionTimeout, err)
+		}
+		if timeout <= 0 {
+			return nil, fmt.Errorf("%s: expected a positive duration, got %s", ConfigFunctionTimeout, text)
+		}
+		merged.FunctionTimeout = timeout
+	}
+
+	if raw, ok := settings[ConfigDedupeSources]; ok {
+		dedupe, ok := raw.(bool)
+		if !ok {
+			return nil, fmt.Errorf("%s: expected a boolean, got %T", ConfigDedupeSources, raw)
+		}
+		merged.DedupeSources = dedupe
+	}
+
+	return merged, nil
+}
+
+// matchesPath reports whether a path glob matches the file or one of the
+// directories containing it. The glob is matched against the trailing
+// elements of the path, so "*.pb.go" matches generated files in any
+// directory and "vendor" every file under a vendor directory.
+func matchesPath(pattern, filename string) bool {
+	elems := strings.Split(strings.ReplaceAll(filename, "\\", "/"), "/")
+	for start := range elems {
+		for end := start + 1; end <= len(elems); end++ {
+			if ok, _ := path.Match(pattern, strings.Join(elems[start:end], "/")); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ruleSettings extracts the rule's section from the gosec configuration.
+func ruleSettings(config map[string]interface{}, ruleID string) map[string]interface{} {
+	if config == nil {
+		return nil
+	}
+	settings, _ := config[ruleID].(map[string]interface{})
+	return settings
+}
@@ -0,0 +1,207 @@
+package taint
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestParseFuncSignature(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		sig  string
+		want funcSignature
+	}{
+		{"os.Getenv", funcSignature{pkg: "os", name: "Getenv"}},
+		{"github.com/acme/web.Param", funcSignature{pkg: "github.com/acme/web", name: "Param"}},
+		{"github.com/acme/web.(*Ctx).Param", funcSignature{pkg: "github.com/acme/web", receiver: "Ctx", name: "Param", pointer: true}},
+		{"github.com/acme/web.(Ctx).Param", funcSignature{pkg: "github.com/acme/web", receiver: "Ctx", name: "Param"}},
+		{"(*github.com/acme/web.Ctx).Param", funcSignature{pkg: "github.com/acme/web", receiver: "Ctx", name: "Param", pointer: true}},
+		{"github.com/acme/web.Ctx.Param", funcSignature{pkg: "github.com/acme/web", receiver: "Ctx", name: "Param", anyRecv: true}},
+		{"gopkg.in/yaml.v3.Unmarshal", funcSignature{pkg: "gopkg.in/yaml.v3", name: "Unmarshal"}},
+		{"gopkg.in/yaml.v3.Decoder.Decode", funcSignature{pkg: "gopkg.in/yaml.v3", receiver: "Decoder", name: "Decode", anyRecv: true}},
+		{"gopkg.in/yaml.v3.(*Decoder).Decode", funcSignature{pkg: "gopkg.in/yaml.v3", receiver: "Decoder", name: "Decode", pointer: true}},
+		{"(*gopkg.in/yaml.v3.Decoder).Decode", funcSignature{pkg: "gopkg.in/yaml.v3", receiver: "Decoder", name: "Decode", pointer: true}},
+	}
+	for _, tt := range tests {
+		got, err := parseFuncSignature(tt.sig)
+		if err != nil {
+			t.Fatalf("parseFuncSignature(%q) returned error: %v", tt.sig, err)
+		}
+		if got != tt.want {
+			t.Fatalf("parseFuncSignature(%q) = %+v, want %+v", tt.sig, got, tt.want)
+		}
+	}
+
+	for _, sig := range []string{"", "Getenv", "os.", "(*os.File.Read", "a.b.c.d", "gopkg.in/yaml.v3.a.b.c"} {
+		if _, err := parseFuncSignature(sig); err == nil {
+			t.Fatalf("expected error for signature %q", sig)
+		}
+	}
+}
+
+func TestMergeRuleConfig(t *testing.T) {
+	t.Parallel()
+
+	base := &Config{
+		Sources:    []Source{{Package: "net/http", Name: "Request", Pointer: true}},
+		Sanitizers: []Sanitizer{{Package: "strconv", Method: "Atoi"}},
+	}
+	merged, err := mergeRuleConfig(base, map[string]interface{}{
+		ConfigSources:    []interface{}{"mycompany/web.(*Ctx).Param"},
+		ConfigSanitizers: []string{"mycompany/dbutil.SafeIdent", "mycompany/dbutil.Escaper.Quote"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(base.Sources) != 1 || len(base.Sanitizers) != 1 {
+		t.Fatalf("base config was modified: %+v", base)
+	}
+	if len(merged.Sources) != 2 {
+		t.Fatalf("expected 2 sources, got %d", len(merged.Sources))
+	}
+	// The receiver form without a pointer marker matches both receiver kinds
+	if len(merged.Sanitizers) != 4 {
+		t.Fatalf("expected 4 sanitizers, got %d", len(merged.Sanitizers))
+	}
+
+	analyzer := New(merged)
+	if _, ok := analyzer.sources["(*mycompany/web.Ctx).Param"]; !ok {
+		t.Fatalf("missing source (*mycompany/web.Ctx).Param")
+	}
+	for _, key := range []string{"mycompany/dbutil.SafeIdent", "(mycompany/dbutil.Escaper).Quote", "(*mycompany/dbutil.Escaper).Quote"} {
+		if _, ok := analyzer.sanitizers[key]; !ok {
+			t.Fatalf("missing sanitizer %s", key)
+		}
+	}
+}
+
+func TestMergeRuleConfigProtoPackages(t *testing.T) {
+	t.Parallel()
+
+	base := &Config{}
+	merged, err := mergeRuleConfig(base, map[string]interface{}{
+		ConfigProtoPackages: []interface{}{"mycompany/api"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if base.ProtoGetters || len(base.ProtoPackages) != 0 {
+		t.Fatalf("base config was modified: %+v", base)
+	}
+	if !merged.ProtoGetters || len(merged.ProtoPackages) != 1 || merged.ProtoPackages[0] != "mycompany/api" {
+		t.Fatalf("expected proto getters from mycompany/api, got %+v", merged)
+	}
+}
+
+func TestMatchesPath(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		pattern  string
+		filename string
+		want     bool
+	}{
+		{"*.pb.go", "/src/app/api/user.pb.go", true},
+		{"*.pb.go", "/src/app/api/user.go", false},
+		{"vendor", "/src/app/vendor/github.com/acme/web/web.go", true},
+		{"vendor", "/src/app/vendors.go", false},
+		{"api/*.go", "/src/app/api/user.go", true},
+		{"api/*.go", "/src/app/api/v1/user.go", false},
+		{"/src/app/gen", "/src/app/gen/user.go", true},
+		{"gen", `C:\src\app\gen\user.go`, true},
+	}
+	for _, tt := range tests {
+		if got := matchesPath(tt.pattern, tt.filename); got != tt.want {
+			t.Errorf("matchesPath(%q, %q) = %v, want %v", tt.pattern, tt.filename, got, tt.want)
+		}
+	}
+}
+
+func TestMergeRuleConfigRejectsInvalidSettings(t *testing.T) {
+	t.Parallel()
+
+	for _, settings := range []map[string]interface{}{
+		{ConfigSources: "os.Getenv"},
+		{ConfigSources: []interface{}{42}},
+		{ConfigSanitizers: []interface{}{"SafeIdent"}},
+		{ConfigGraphDir: true},
+		{ConfigMaxCallDepth: float64(0)},
+		{ConfigMaxCallDepth: 1.5},
+		{ConfigMaxCallDepth: "3"},
+		{ConfigProtoPackages: "mycompany/api"},
+		{ConfigExcludePaths: "vendor"},
+		{ConfigExcludePaths: []interface{}{"[vendor"}},
+		{ConfigFunctionTimeout: float64(5)},
+		{ConfigFunctionTimeout: "5"},
+		{ConfigFunctionTimeout: "0s"},
+		{ConfigFunctionTimeout: "-1s"},
+		{ConfigDedupeSources: "true"},
+	} {
+		if _, err := mergeRuleConfig(&Config{}, settings); err == nil {
+			t.Fatalf("expected error for settings %v", settings)
+		}
+	}
+}
+
+func TestMergeRuleConfigSinks(t *testing.T) {
+	t.Parallel()
+
+	merged, err := mergeRuleConfig(&Config{}, map[string]interface{}{
+		ConfigSinks: []interface{}{
+			"mycompany/log.Audit",
+			map[string]interface{}{"signature": "mycompany/store.Run", "args": []interface{}{float64(0)}},
+			map[string]interface{}{"signature": "mycompany/store.(*Store).Exec", "args": []interface{}{float64(1)}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := map[string][]int{
+		"mycompany/log.Audit":           nil,
+		"mycompany/store.Run":           {0},
+		"(*mycompany/store.Store).Exec": {2}, // shifted past the receiver
+	}
+	if len(merged.Sinks) != len(want) {
+		t.Fatalf("expected %d sinks, got %d", len(want), len(merged.Sinks))
+	}
+	for _, sink := range merged.Sinks {
+		args, ok := want[formatSinkKey(sink)]
+		if !ok {
+			t.Fatalf("unexpected sink %s", formatSinkKey(sink))
+		}
+		if fmt.Sprint(sink.CheckArgs) != fmt.Sprint(args) {
+			t.Fatalf("sink %s checks %v, want %v", formatSinkKey(sink), sink.CheckArgs, args)
+		}
+	}
+
+	for _, sinks := range []interface{}{
+		[]interface{}{map[string]interface{}{"args": []interface{}{float64(0)}}},
+		[]interface{}{map[string]interface{}{"signature": "mycompany/store.Run", "args": []interface{}{float64(-1)}}},
+		[]interface{}{map[string]interface{}{"signature": "mycompany/store.Run", "args": []interface{}{0.5}}},
+		[]interface{}{42},
+	} {
+		if _, err := mergeRuleConfig(&Config{}, map[string]interface{}{ConfigSinks: sinks}); err == nil {
+			t.Fatalf("expected error for sinks %v", sinks)
+		}
+	}
+}
+
+func TestFunctionKeys(t *testing.T) {
+	t.Parallel()
+
+	keys, err := FunctionKeys([]interface{}{"mycompany/rpc.(*Client).Call", "mycompany/rpc.Dial", "mycompany/rpc.Conn.Read"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, key := range []string{"(*mycompany/rpc.Client).Call", "mycompany/rpc.Dial", "(mycompany/rpc.Conn).Read", "(*mycompany/rpc.Conn).Read"} {
+		if _, ok := keys[key]; !ok {
+			t.Fatalf("missing key %s in %v", key, keys)
+		}
+	}
+
+	if _, err := FunctionKeys([]interface{}{"Dial"}); err == nil {
+		t.Fatalf("expected error for unqualified signature")
+	}
+}
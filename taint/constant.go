@@ -0,0 +1,136 @@
+package taint
+
+import (
+	"go/types"
+
+	"golang.org/x/tools/go/ssa"
+)
+
+// constants holds the values found to be provably constant before the
+// analysis starts. Such values are built only from compile-time constants,
+// e.g. "SELECT * FROM " + tableName() where tableName returns a constant, so
+// the taint search never has to look past them. The sets are read-only once
+// built and shared by all workers.
+type constants struct {
+	values map[ssa.Value]bool
+	funcs  map[*ssa.Function]bool // functions whose result is constant on every return
+}
+
+// isConstant reports whether v is a constant or was found to be one.
+func (c *constants) isConstant(v ssa.Value) bool {
+	if _, ok := v.(*ssa.Const); ok {
+		return true
+	}
+	return c != nil && c.values[v]
+}
+
+// markConstants finds the provably constant values of srcFuncs and of the
+// functions of the same packages they call. Values are marked until a fixed
+// point is reached, so constants flow through any number of helpers.
+func (a *Analyzer) markConstants(srcFuncs []*ssa.Function) *constants {
+	c := &constants{
+		values: make(map[ssa.Value]bool),
+		funcs:  make(map[*ssa.Function]bool),
+	}
+	funcs := packageCallees(srcFuncs)
+	for changed := true; changed; {
+		changed = false
+		for _, fn := range funcs {
+			for _, block := range fn.Blocks {
+				for _, instr := range block.Instrs {
+					v, ok := instr.(ssa.Value)
+					if !ok || c.values[v] || !a.foldsToConstant(v, c) {
+						continue
+					}
+					c.values[v] = true
+					changed = true
+				}
+			}
+			if !c.funcs[fn] && returnsConstant(fn, c) {
+				c.funcs[fn] = true
+				changed = true
+			}
+		}
+	}
+	return c
+}
+
+// foldsToConstant reports whether v is computed only from constants. Only
+// values of basic types are considered: they cannot be written through later.
+func (a *Analyzer) foldsToConstant(v ssa.Value, c *constants) bool {
+	if _, ok := v.Type().Underlying().(*types.Basic); !ok {
+		return false
+	}
+	switch val := v.(type) {
+	case *ssa.BinOp:
+		return c.isConstant(val.X) && c.isConstant(val.Y)
+	case *ssa.Convert:
+		return c.isConstant(val.X)
+	case *ssa.ChangeType:
+		return c.isConstant(val.X)
+	case *ssa.Phi:
+		for _, edge := range val.Edges {
+			if !c.isConstant(edge) {
+				return false
+			}
+		}
+		return true
+	case *ssa.Call:
+		// A function configured as a source is tainted whatever it returns
+		callee := val.Call.StaticCallee()
+		return callee != nil && c.funcs[callee] && !a.isSourceFuncCall(val)
+	}
+	return false
+}
+
+// returnsConstant reports whether fn has a single result that is constant on
+// every return.
+func returnsConstant(fn *ssa.Function, c *constants) bool {
+	if fn.Signature.Results().Len() != 1 {
+		return false
+	}
+	returns := 0
+	for _, block := range fn.Blocks {
+		if ret, ok := block.Instrs[len(block.Instrs)-1].(*ssa.Return); ok {
+			if !c.isConstant(ret.Results[0]) {
+				return false
+			}
+			returns++
+		}
+	}
+	return returns > 0
+}
+
+// packageCallees returns srcFuncs together with the functions with bodies
+// they call, directly or not, in the packages of srcFuncs.
+func packageCallees(srcFuncs []*ssa.Function) []*ssa.Function {
+	pkgs := make(map[*ssa.Package]bool)
+	for _, fn := range srcFuncs {
+		pkgs[fn.Pkg] = true
+	}
+	seen := make(map[*ssa.Function]bool)
+	var funcs []*ssa.Function
+	var visit func(fn *ssa.Function)
+	visit = func(fn *ssa.Function) {
+		if seen[fn] || fn.Blocks == nil {
+			return
+		}
+		seen[fn] = true
+		funcs = append(funcs, fn)
+		for _, block := range fn.Blocks {
+			for _, instr := range block.Instrs {
+				call, ok := instr.(ssa.CallInstruction)
+				if !ok {
+					continue
+				}
+				if callee := call.Common().StaticCallee(); callee != nil && pkgs[callee.Pkg] {
+					visit(callee)
+				}
+			}
+		}
+	}
+	for _, fn := range srcFuncs {
+		visit(fn)
+	}
+	return funcs
+}
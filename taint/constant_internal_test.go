@@ -0,0 +1,97 @@
+package taint
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+
+	"golang.org/x/tools/go/ssa"
+)
+
+func TestMarkConstantsFoldsHelperResults(t *testing.T) {
+	t.Parallel()
+
+	src := `package p
+
+type DB struct{}
+
+func (d *DB) Query(q string) {}
+
+func input() string { return "" }
+
+const table = "users"
+
+func tableName() string { return table }
+
+func tableFor(kind string) string {
+	if kind == "admin" {
+		return "admins"
+	}
+	return tableName()
+}
+
+func constant(d *DB) {
+	d.Query("SELECT * FROM " + tableFor(input()))
+}
+
+func tainted(d *DB) {
+	d.Query("SELECT * FROM " + tableName() + " WHERE a = " + input())
+}
+`
+	fset := token.NewFileSet()
+	parsed, err := parser.ParseFile(fset, "p.go", src, 0)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	info := &types.Info{
+		Types:      make(map[ast.Expr]types.TypeAndValue),
+		Defs:       make(map[*ast.Ident]types.Object),
+		Uses:       make(map[*ast.Ident]types.Object),
+		Implicits:  make(map[ast.Node]types.Object),
+		Scopes:     make(map[ast.Node]*types.Scope),
+		Selections: make(map[*ast.SelectorExpr]*types.Selection),
+	}
+	pkg, err := (&types.Config{}).Check("p", fset, []*ast.File{parsed}, info)
+	if err != nil {
+		t.Fatalf("type-check: %v", err)
+	}
+	prog := ssa.NewProgram(fset, ssa.BuilderMode(0))
+	ssaPkg := prog.CreatePackage(pkg, []*ast.File{parsed}, info, true)
+	prog.Build()
+
+	analyzer := New(helperCallsConfig)
+	srcFuncs := []*ssa.Function{ssaPkg.Func("constant"), ssaPkg.Func("tainted")}
+	c := analyzer.markConstants(srcFuncs)
+
+	// Helpers reached from the analyzed functions are folded too
+	for _, name := range []string{"tableName", "tableFor"} {
+		if !c.funcs[ssaPkg.Func(name)] {
+			t.Errorf("expected %s to return a constant", name)
+		}
+	}
+
+	queryArg := func(fn *ssa.Function) ssa.Value {
+		for _, block := range fn.Blocks {
+			for _, instr := range block.Instrs {
+				if call, ok := instr.(*ssa.Call); ok && call.Call.StaticCallee().Name() == "Query" {
+					return call.Call.Args[1]
+				}
+			}
+		}
+		t.Fatalf("no query in %s", fn.Name())
+		return nil
+	}
+	if !c.isConstant(queryArg(srcFuncs[0])) {
+		t.Error("expected the query of constant to be constant")
+	}
+	// input returns a constant, but is configured as a source
+	if c.isConstant(queryArg(srcFuncs[1])) {
+		t.Error("expected the query of tainted not to be constant")
+	}
+
+	if results := analyzer.Analyze(prog, srcFuncs); len(results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(results))
+	}
+}
@@ -0,0 +1,151 @@
+package taint
+
+import (
+	"go/types"
+	"slices"
+
+	"golang.org/x/tools/go/ssa"
+)
+
+// documentValues returns the parts of the sink argument v to check for taint
+// when the sink has DocumentKeys. If v is a document literal built in the
+// calling function, such as bson.M{"_id": id} or bson.D{{"$where", js}}, they
+// are the values stored under one of the keys, the keys that are not
+// constants and the values that are neither constants of a basic type nor
+// documents themselves; nested documents are searched in turn. A string
+// compared with a field is data, while under "$where" it is code. Any other
+// argument is checked as a whole.
+func documentValues(v ssa.Value, keys []string) []ssa.Value {
+	switch unwrapDocument(v).(type) {
+	case *ssa.MakeMap, *ssa.Slice:
+		return documentParts(v, keys)
+	}
+	return []ssa.Value{v}
+}
+
+// documentParts implements documentValues for a value stored in a document.
+func documentParts(v ssa.Value, keys []string) []ssa.Value {
+	switch doc := unwrapDocument(v).(type) {
+	case *ssa.MakeMap:
+		var parts []ssa.Value
+		for _, ref := range *doc.Referrers() {
+			if update, ok := ref.(*ssa.MapUpdate); ok && update.Map == doc {
+				parts = append(parts, entryParts(update.Key, update.Value, keys)...)
+			}
+		}
+		return parts
+	case *ssa.Slice:
+		if elems, ok := sliceLiteral(doc); ok {
+			var parts []ssa.Value
+			for _, elem := range elems {
+				if key, value, ok := keyValueElem(elem); ok {
+					parts = append(parts, entryParts(key, value, keys)...)
+					continue
+				}
+				for _, value := range storedValues(elem) {
+					parts = append(parts, documentParts(value, keys)...)
+				}
+			}
+			return parts
+		}
+	}
+	if _, ok := unwrapDocument(v).Type().Underlying().(*types.Basic); ok {
+		return nil
+	}
+	return []ssa.Value{v}
+}
+
+// entryParts returns the parts of a document entry to check for taint.
+func entryParts(key, value ssa.Value, keys []string) []ssa.Value {
+	if key == nil {
+		return documentParts(value, keys)
+	}
+	name, ok := stringConst(key)
+	switch {
+	case !ok:
+		// A key chosen by the caller can name any operator
+		return append([]ssa.Value{key}, documentParts(value, keys)...)
+	case slices.Contains(keys, name):
+		return []ssa.Value{value}
+	}
+	return documentParts(value, keys)
+}
+
+// unwrapDocument looks through the interface and named type conversions of
+// a document passed as an argument or nested in another document.
+func unwrapDocument(v ssa.Value) ssa.Value {
+	for {
+		switch w := v.(type) {
+		case *ssa.MakeInterface:
+			v = w.X
+		case *ssa.ChangeType:
+			v = w.X
+		default:
+			return v
+		}
+	}
+}
+
+// sliceLiteral returns the element addresses of the composite literal that
+// s slices.
+func sliceLiteral(s *ssa.Slice) ([]*ssa.IndexAddr, bool) {
+	alloc, ok := s.X.(*ssa.Alloc)
+	if !ok || alloc.Comment != "slicelit" {
+		return nil, false
+	}
+	var elems []*ssa.IndexAddr
+	for _, ref := range *alloc.Referrers() {
+		if elem, ok := ref.(*ssa.IndexAddr); ok {
+			elems = append(elems, elem)
+		}
+	}
+	return elems, true
+}
+
+// keyValueElem returns the key and the value stored in elem if it is a
+// struct with Key and Value fields, like bson.E. The key is nil if it is
+// never stored.
+func keyValueElem(elem *ssa.IndexAddr) (key, value ssa.Value, ok bool) {
+	st, isStruct := elem.Type().(*types.Pointer).Elem().Underlying().(*types.Struct)
+	if !isStruct || !hasField(st, "Key") || !hasField(st, "Value") {
+		return nil, nil, false
+	}
+	for _, ref := range *elem.Referrers() {
+		field, isField := ref.(*ssa.FieldAddr)
+		if !isField {
+			continue
+		}
+		stored := storedValues(field)
+		if len(stored) == 0 {
+			continue
+		}
+		switch st.Field(field.Field).Name() {
+		case "Key":
+			key = stored[len(stored)-1]
+		case "Value":
+			value = stored[len(stored)-1]
+		}
+	}
+	return key, value, value != nil
+}
+
+// hasField reports whether st has a field with the given name.
+func hasField(st *types.Struct, name string) bool {
+	for i := range st.NumFields() {
+		if st.Field(i).Name() == name {
+			return true
+		}
+	}
+	return false
+}
+
+// storedValues returns the values stored to addr.
+func storedValues(addr ssa.Value) []ssa.Value {
+	var values []ssa.Value
+	for _, ref := range *addr.Referrers() {
+		if store, ok := ref.(*ssa.Store); ok && store.Addr == addr {
+			values = append(values, store.Val)
+		}
+	}
+	return values
+}
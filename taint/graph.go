@@ -0,0 +1,111 @@
+package taint
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/ssa"
+)
+
+// funcGraph is the taint graph of a function: its sinks and, for each
+// tainted one, the values through which the taint reached it.
+type funcGraph struct {
+	fn    *ssa.Function
+	sinks []ssa.Instruction
+	flows []sinkFlow
+}
+
+// sinkFlow is the search path from a tainted argument of sink back to the
+// source, as recorded by isTainted.
+type sinkFlow struct {
+	sink ssa.Instruction
+	path []ssa.Value
+}
+
+// WriteGraphs writes the taint graph of every analyzed function with
+// sinks to a Graphviz DOT file in the configured GraphDir. The files are
+// named after the functions.
+func (a *Analyzer) WriteGraphs() error {
+	if a.config.GraphDir == "" || len(a.graphs) == 0 {
+		return nil
+	}
+	if err := os.MkdirAll(a.config.GraphDir, 0o750); err != nil {
+		return err
+	}
+	for _, graph := range a.graphs {
+		path := filepath.Join(a.config.GraphDir, graphFileName(graph.fn))
+		if err := os.WriteFile(path, graph.dot(), 0o600); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// graphFileName returns the name of the DOT file of fn, keeping only the
+// characters of its full name that are safe in file names.
+func graphFileName(fn *ssa.Function) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-':
+			return r
+		}
+		return '_'
+	}, fn.String())
+	return name + ".dot"
+}
+
+// dot renders the graph in the Graphviz DOT language. Edges follow the data
+// from the source to the sink. Sources are drawn red and sinks as boxes,
+// dashed when no tainted data reaches them.
+func (g *funcGraph) dot() []byte {
+	var buf bytes.Buffer
+	ids := make(map[any]string)
+	node := func(v any, label string, attrs string) string {
+		if id, ok := ids[v]; ok {
+			return id
+		}
+		id := "n" + strconv.Itoa(len(ids))
+		ids[v] = id
+		fmt.Fprintf(&buf, "\t%s [label=%s%s];\n", id, strconv.Quote(label), attrs)
+		return id
+	}
+
+	name := g.fn.String()
+	fmt.Fprintf(&buf, "digraph %s {\n", strconv.Quote(name))
+	fmt.Fprintf(&buf, "\tlabel=%s;\n", strconv.Quote(name))
+
+	tainted := make(map[ssa.Instruction]bool)
+	for _, flow := range g.flows {
+		tainted[flow.sink] = true
+	}
+	for _, sink := range g.sinks {
+		attrs := ", shape=box"
+		if !tainted[sink] {
+			attrs += ", style=dashed"
+		}
+		node(sink, "sink: "+describeSink(sink), attrs)
+	}
+
+	var edges []string
+	for _, flow := range g.flows {
+		to := ids[flow.sink]
+		for i, v := range flow.path {
+			label, attrs := describeValue(v), ""
+			if i == len(flow.path)-1 {
+				label, attrs = "source: "+label, ", color=red"
+			}
+			from := node(v, label, attrs)
+			edges = append(edges, fmt.Sprintf("\t%s -> %s;\n", from, to))
+			to = from
+		}
+	}
+	for _, edge := range edges {
+		buf.WriteString(edge)
+	}
+	buf.WriteString("}\n")
+	return buf.Bytes()
+}
@@ -0,0 +1,135 @@
+package taint_test
+
+import (
+	"fmt"
+	"go/types"
+	"runtime"
+	"slices"
+	"sync"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"golang.org/x/tools/go/ssa"
+	"golang.org/x/tools/go/ssa/ssautil"
+
+	"github.com/securego/gosec/v2/analyzers"
+	"github.com/securego/gosec/v2/taint"
+	"github.com/securego/gosec/v2/testutils"
+)
+
+// sampleProgram is the SSA form of a code sample with the functions declared
+// in its source, as the taint runner receives them.
+type sampleProgram struct {
+	prog  *ssa.Program
+	funcs []*ssa.Function
+}
+
+// g701Programs builds the bundled G701 samples once for all tests and benchmarks.
+var g701Programs = sync.OnceValues(func() ([]sampleProgram, error) {
+	programs := make([]sampleProgram, 0, len(testutils.SampleCodeG701))
+	for i, sample := range testutils.SampleCodeG701 {
+		program, err := buildSampleProgram(sample)
+		if err != nil {
+			return nil, fmt.Errorf("sample %d: %w", i, err)
+		}
+		programs = append(programs, program)
+	}
+	return programs, nil
+})
+
+func buildSampleProgram(sample testutils.CodeSample) (sampleProgram, error) {
+	pkg := testutils.NewTestPackage()
+	defer pkg.Close()
+	for i, code := range sample.Code {
+		pkg.AddFile(fmt.Sprintf("sample_%d.go", i), code)
+	}
+	if err := pkg.Build(); err != nil {
+		return sampleProgram{}, err
+	}
+	pkgs := pkg.Pkgs()
+	if len(pkgs) != 1 || pkgs[0].IllTyped {
+		return sampleProgram{}, fmt.Errorf("sample does not type-check")
+	}
+	p := pkgs[0]
+
+	// Dependencies are created from their type information only, as the
+	// buildssa analyzer does.
+	prog := ssa.NewProgram(p.Fset, ssa.BuilderMode(0))
+	created := make(map[*types.Package]bool)
+	var createAll func([]*types.Package)
+	createAll = func(imports []*types.Package) {
+		for _, imp := range imports {
+			if !created[imp] {
+				created[imp] = true
+				prog.CreatePackage(imp, nil, nil, true)
+				createAll(imp.Imports())
+			}
+		}
+	}
+	createAll(p.Types.Imports())
+	ssaPkg := prog.CreatePackage(p.Types, p.Syntax, p.TypesInfo, false)
+	ssaPkg.Build()
+
+	var funcs []*ssa.Function
+	for fn := range ssautil.AllFunctions(prog) {
+		if fn.Pkg == ssaPkg && fn.Synthetic == "" {
+			funcs = append(funcs, fn)
+		}
+	}
+	slices.SortFunc(funcs, func(a, b *ssa.Function) int { return int(a.Pos() - b.Pos()) })
+	return sampleProgram{prog: prog, funcs: funcs}, nil
+}
+
+// analyzeSample runs the analysis with the given number of workers and
+// returns the findings as "position sink" strings.
+func analyzeSample(program sampleProgram, config *taint.Config, workers int) []string {
+	analyzer := taint.New(config)
+	analyzer.SetWorkers(workers)
+	var findings []string
+	for _, result := range analyzer.Analyze(program.prog, program.funcs) {
+		pos := program.prog.Fset.Position(result.SinkPos)
+		findings = append(findings, fmt.Sprintf("%d:%d %s.%s", pos.Line, pos.Column, result.Sink.Package, result.Sink.Method))
+	}
+	return findings
+}
+
+var _ = Describe("Parallel analysis", func() {
+	It("should report the same findings in the same order for any number of workers", func() {
+		programs, err := g701Programs()
+		Expect(err).ShouldNot(HaveOccurred())
+		config := analyzers.SQLInjection()
+
+		total := 0
+		for i, program := range programs {
+			serial := analyzeSample(program, &config, 1)
+			total += len(serial)
+			for _, workers := range []int{2, 4, 16} {
+				Expect(analyzeSample(program, &config, workers)).Should(Equal(serial), "sample %d with %d workers", i, workers)
+			}
+		}
+		Expect(total).Should(BeNumerically(">", 0))
+	})
+})
+
+func benchmarkAnalyzeSamples(b *testing.B, workers int) {
+	programs, err := g701Programs()
+	if err != nil {
+		b.Fatal(err)
+	}
+	config := analyzers.SQLInjection()
+
+	for b.Loop() {
+		for _, program := range programs {
+			analyzeSample(program, &config, workers)
+		}
+	}
+}
+
+func BenchmarkAnalyzeSamplesSerial(b *testing.B) {
+	benchmarkAnalyzeSamples(b, 1)
+}
+
+func BenchmarkAnalyzeSamplesParallel(b *testing.B) {
+	benchmarkAnalyzeSamples(b, runtime.GOMAXPROCS(0))
+}
@@ -0,0 +1,99 @@
+package taint
+
+import (
+	"go/types"
+
+	"golang.org/x/tools/go/ssa"
+)
+
+// syncMapStoreArgs lists the methods of sync.Map and sync.Pool that store a
+// value, with the index of the stored value among the call arguments.
+var syncMapStoreArgs = map[string]int{
+	"(*sync.Map).Store":          2,
+	"(*sync.Map).LoadOrStore":    2,
+	"(*sync.Map).Swap":           2,
+	"(*sync.Map).CompareAndSwap": 3,
+	"(*sync.Pool).Put":           1,
+}
+
+// syncMapLoads lists the methods of sync.Map and sync.Pool that return a
+// stored value.
+var syncMapLoads = map[string]bool{
+	"(*sync.Map).Load":          true,
+	"(*sync.Map).LoadOrStore":   true,
+	"(*sync.Map).LoadAndDelete": true,
+	"(*sync.Map).Swap":          true,
+	"(*sync.Pool).Get":          true,
+}
+
+// structField identifies a field of every value of a struct type.
+type structField struct {
+	typ   types.Type
+	field int
+}
+
+// syncMapID identifies the sync.Map or sync.Pool that the receiver m points to: a
+// package-level variable or one of its fields, a local variable, or a field
+// of any value of a struct type. It returns nil for other receivers, such as
+// a *sync.Map parameter.
+func syncMapID(m ssa.Value) any {
+	switch addr := m.(type) {
+	case *ssa.Global:
+		return globalField{global: addr, field: wholeGlobal}
+	case *ssa.Alloc:
+		return addr
+	case *ssa.FieldAddr:
+		if global, ok := addr.X.(*ssa.Global); ok {
+			return globalField{global: global, field: addr.Field}
+		}
+		if ptr, ok := addr.X.Type().Underlying().(*types.Pointer); ok {
+			return structField{typ: ptr.Elem(), field: addr.Field}
+		}
+	}
+	return nil
+}
+
+// indexSyncMapStores records every call storing a value in a sync.Map or
+// putting one in a sync.Pool made by the given functions, keyed by syncMapID.
+func indexSyncMapStores(funcs []*ssa.Function) map[any][]*ssa.Call {
+	stores := make(map[any][]*ssa.Call)
+	for _, fn := range funcs {
+		for _, block := range fn.Blocks {
+			for _, instr := range block.Instrs {
+				call, ok := instr.(*ssa.Call)
+				if !ok {
+					continue
+				}
+				callee := call.Call.StaticCallee()
+				if callee == nil {
+					continue
+				}
+				if _, ok := syncMapStoreArgs[callee.String()]; !ok {
+					continue
+				}
+				if id := syncMapID(call.Call.Args[0]); id != nil {
+					stores[id] = append(stores[id], call)
+				}
+			}
+		}
+	}
+	return stores
+}
+
+// isSyncMapTainted checks if a value loaded from a sync.Map is tainted. Keys
+// are not tracked, so the value is tainted if any value stored in the same
+// map is, like a lookup in a plain map. Likewise, any value got from a
+// sync.Pool is tainted if a tainted value was ever put in the pool.
+func (a *Analyzer) isSyncMapTainted(load *ssa.Call, visited map[ssa.Value]bool, depth int) bool {
+	id := syncMapID(load.Call.Args[0])
+	if id == nil {
+		return false
+	}
+	for _, store := range a.syncMapStores[id] {
+		idx := syncMapStoreArgs[store.Call.StaticCallee().String()]
+		if a.isTainted(store.Call.Args[idx], store.Parent(), visited, depth+1) {
+			return true
+		}
+	}
+	return false
+}
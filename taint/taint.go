@@ -2,6 +2,11 @@
 // It tracks data flow from sources (user input) to sinks (dangerous functions)
 // using SSA form and call graph analysis.
 //
+// Other tools can define their own rules: New builds an Analyzer from a
+// Config, AddSource, AddSink and AddSanitizer register more of them, and
+// Analyze returns the flows found in the given functions. NewGosecAnalyzer
+// wraps a rule into an analysis.Analyzer, as the gosec G7xx rules do.
+//
 // This implementation uses only golang.org/x/tools packages which gosec
 // already depends on - no external dependencies required.
 //
@@ -11,13 +16,24 @@
 package taint
 
 import (
+	"cmp"
+	"fmt"
+	"go/ast"
+	"go/constant"
 	"go/token"
 	"go/types"
+	"runtime"
+	"slices"
 	"strings"
+	"sync"
+	"time"
 
+	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/callgraph"
 	"golang.org/x/tools/go/callgraph/cha"
 	"golang.org/x/tools/go/ssa"
+
+	"github.com/securego/gosec/v2/issue"
 )
 
 // maxTaintDepth limits recursion depth to prevent stack overflow on large codebases
@@ -29,6 +45,68 @@ const maxTaintDepth = 50
 // Real taint flows come from direct/nearby callers, not the 33rd+ CHA-generated edge.
 const maxCallerEdges = 32
 
+// maxFieldPathDepth limits how many levels of a nested field path such as
+// req.Query.Filter.SQL are resolved when looking for stores to a field.
+const maxFieldPathDepth = 8
+
+// bufferWriteMethods lists methods that copy their arguments into the
+// receiver's buffer. Once a tainted argument is written, everything later
+// read from the buffer (String, Bytes, ...) is tainted.
+var bufferWriteMethods = map[string]bool{
+	"(*strings.Builder).Write":       true,
+	"(*strings.Builder).WriteByte":   true,
+	"(*strings.Builder).WriteRune":   true,
+	"(*strings.Builder).WriteString": true,
+	"(*bytes.Buffer).Write":          true,
+	"(*bytes.Buffer).WriteByte":      true,
+	"(*bytes.Buffer).WriteRune":      true,
+	"(*bytes.Buffer).WriteString":    true,
+	"(*bytes.Buffer).ReadFrom":       true,
+}
+
+// writerFuncs lists functions that write their remaining arguments to the
+// io.Writer passed as the first argument.
+var writerFuncs = map[string]bool{
+	"fmt.Fprint":     true,
+	"fmt.Fprintf":    true,
+	"fmt.Fprintln":   true,
+	"io.WriteString": true,
+	"io.Copy":        true,
+}
+
+// decodeFuncs lists functions that fill the value their last argument points
+// to from the data in their other arguments or in their receiver.
+var decodeFuncs = map[string]bool{
+	"encoding/json.Unmarshal":                              true,
+	"(*encoding/json.Decoder).Decode":                      true,
+	"encoding/xml.Unmarshal":                               true,
+	"(*encoding/xml.Decoder).Decode":                       true,
+	"go.mongodb.org/mongo-driver/bson.Unmarshal":           true,
+	"go.mongodb.org/mongo-driver/bson.UnmarshalExtJSON":    true,
+	"go.mongodb.org/mongo-driver/v2/bson.Unmarshal":        true,
+	"go.mongodb.org/mongo-driver/v2/bson.UnmarshalExtJSON": true,
+}
+
+// formatFuncs lists functions that return the formatted text of their
+// arguments. A verb such as %d or %q changes how an argument is printed, but
+// never drops it: fmt.Sprintf("%d", "1 OR 1=1") yields "%!d(string=1 OR 1=1)".
+var formatFuncs = map[string]bool{
+	"fmt.Sprint":   true,
+	"fmt.Sprintf":  true,
+	"fmt.Sprintln": true,
+	"fmt.Errorf":   true,
+	"fmt.Append":   true,
+	"fmt.Appendf":  true,
+	"fmt.Appendln": true,
+}
+
+// marshalFuncs lists functions that encode the whole value of their first
+// argument. Their result is tainted if the value or any of its fields is.
+var marshalFuncs = map[string]bool{
+	"encoding/json.Marshal":       true,
+	"encoding/json.MarshalIndent": true,
+}
+
 // isContextType checks if a type is context.Context.
 // context.Context is a control-flow mechanism (deadlines, cancellation, request-scoped values)
 // that does not carry user-controlled data relevant to taint sinks like XSS.
@@ -64,6 +142,10 @@ type Source struct {
 	// (e.g., os.Getenv, os.ReadFile). When false, Source is treated as a type
 	// that is only tainted when received as a function parameter from external callers.
 	IsFunc bool
+	// Receiver is the type name for method sources (e.g., "Ctx" for (*web.Ctx).Param),
+	// or empty for package-level functions and types. When set, Name is the method
+	// name and Pointer applies to the receiver.
+	Receiver string
 }
 
 // Sink defines a dangerous function that should not receive tainted data.
@@ -91,6 +173,29 @@ type Sink struct {
 	// The sink only fires when every guarded argument's type implements (or equals)
 	// the named interface/type. When empty, no type constraint is applied.
 	ArgTypeGuards map[int]string
+
+	// ArgValues constrains arguments to string constants before treating a
+	// call as a sink, e.g. {1: "Location"} for (net/http.Header).Set. Values
+	// are compared ignoring case, as header names are. When empty, any
+	// argument values are accepted.
+	ArgValues map[int]string
+
+	// Conversion marks a type rather than a function: converting a tainted
+	// value to the named type Package.Method is the sink, as for
+	// html/template.HTML, which disables escaping of its value.
+	Conversion bool
+
+	// Field marks a struct field rather than a method: storing a tainted value
+	// to the field Method of the type Receiver is the sink, as for the Env
+	// field of os/exec.Cmd, which sets the environment of the command.
+	Field bool
+
+	// DocumentKeys marks the checked arguments as query documents, such as
+	// bson.M filters, in which only the values under these keys are code,
+	// e.g. "$where". A document literal is only reported for tainted values
+	// under them or tainted keys, not for tainted strings compared with a
+	// field. See documentValues.
+	DocumentKeys []string
 }
 
 // resolveOriginalType traces back through SSA interface-conversion instructions
@@ -155,6 +260,21 @@ func guardsSatisfied(args []ssa.Value, sink Sink, prog *ssa.Program) bool {
 	return true
 }
 
+// argValuesMatch returns true when every argument listed in sink.ArgValues is
+// a string constant equal to the expected value, ignoring case.
+func argValuesMatch(args []ssa.Value, sink Sink) bool {
+	for argIdx, want := range sink.ArgValues {
+		if argIdx >= len(args) {
+			return false
+		}
+		c, ok := args[argIdx].(*ssa.Const)
+		if !ok || c.Value == nil || c.Value.Kind() != constant.String || !strings.EqualFold(constant.StringVal(c.Value), want) {
+			return false
+		}
+	}
+	return true
+}
+
 // lookupNamedType resolves a fully-qualified type string of the form
 // "import/path.TypeName" to a types.Type using the SSA program's package set.
 // Returns nil when the package or type name is not found.
@@ -192,6 +312,41 @@ type Sanitizer struct {
 	Method string
 	// Pointer indicates whether the receiver is a pointer type
 	Pointer bool
+	// Strips, if non-empty, limits the sanitizer to calls that remove each of
+	// these substrings, for functions replacing a constant old substring
+	// (Args[1]) by a constant new one (Args[2]) like strings.ReplaceAll.
+	// Calls may be chained, each removing one of them:
+	//	strings.ReplaceAll(strings.ReplaceAll(v, "\r", ""), "\n", "")
+	Strips []string
+}
+
+// Guard is a boolean check that validates the value passed as its first
+// argument on one of its branches. A sink argument is not tainted where
+// every path to the sink goes through a validating branch of a guard.
+type Guard struct {
+	// Package is the import path (e.g., "strings")
+	Package string
+	// Method is the function name (e.g., "HasPrefix")
+	Method string
+	// Negated guards validate on the false branch (e.g., !strings.Contains(p, ".."))
+	Negated bool
+	// Arg, if non-empty, is the constant the second argument must equal
+	Arg string
+	// Normalizers, if non-empty, are the functions the checked value must be
+	// the result of (e.g., filepath.Clean before a prefix check)
+	Normalizers []Sanitizer
+}
+
+// AllowlistKey is a part of a value that, once checked against a fixed
+// allowlist, validates the whole value for a rule, such as the host of a URL
+// for SSRF.
+type AllowlistKey struct {
+	// Package is the import path of the value's type (e.g., "net/url")
+	Package string
+	// Type is the name of the value's type (e.g., "URL")
+	Type string
+	// Field is the field or method that reads the key (e.g., "Host")
+	Field string
 }
 
 // Result represents a detected taint flow from source to sink.
@@ -204,6 +359,31 @@ type Result struct {
 	SinkPos token.Pos
 	// Path is the sequence of functions from entry point to the sink
 	Path []*ssa.Function
+	// Flow is the sequence of values the tainted data passes through, from
+	// the source to the sink call
+	Flow []FlowStep
+	// Approximate is set when the flow relies on an over-approximation, as
+	// listed in Approximations, such as a map with one tainted value, so that
+	// the sink may only receive untainted elements of it
+	Approximate bool
+	// Approximations are the steps of the flow where the analysis widened
+	// taint, such as a map tracked as a whole or a call depth cap reached,
+	// with a description of the approximation
+	Approximations []FlowStep
+	// MergedSinks are the sink calls of the other flows from the same source,
+	// collapsed into this one when Config.DedupeSources is set
+	MergedSinks []FlowStep
+}
+
+// FlowStep is a single step of a taint flow.
+type FlowStep struct {
+	// Pos is the source code position of the value
+	Pos token.Pos
+	// Description is the SSA form of the value
+	Description string
+	// Kind is the SSA category of the value, one of the issue.FlowKind
+	// constants
+	Kind string
 }
 
 // Config holds taint analysis configuration.
@@ -214,67 +394,232 @@ type Config struct {
 	Sinks []Sink
 	// Sanitizers is the list of functions that neutralize taint (optional)
 	Sanitizers []Sanitizer
+	// Guards is the list of branch conditions that validate a value (optional)
+	Guards []Guard
+	// AllowlistKeys is the list of value parts that validate a value when
+	// allowlisted (optional)
+	AllowlistKeys []AllowlistKey
+	// GraphDir is a directory to write the taint graph of each function with
+	// sink calls to, for debugging (optional)
+	GraphDir string
+	// MaxCallDepth caps the number of calls followed from a sink; zero means
+	// no cap (optional)
+	MaxCallDepth int
+	// ProtoGetters makes the Get methods of protobuf messages that return a
+	// string sources, such as GetName on a gRPC request message (optional)
+	ProtoGetters bool
+	// ProtoPackages are package path prefixes whose types are protobuf
+	// messages, besides those recognized by their generated code (optional)
+	ProtoPackages []string
+	// ExcludePaths are path globs of files whose sinks are not checked, such
+	// as generated or vendored code. Their functions are still followed when
+	// tracing taint from sinks in other files (optional)
+	ExcludePaths []string
+	// FunctionTimeout caps the time spent checking the sinks of one function;
+	// a function taking longer is skipped and reported by TimedOut. Zero means
+	// no limit (optional)
+	FunctionTimeout time.Duration
+	// DedupeSources keeps a single result per source reaching several sinks,
+	// the one with the earliest sink, and lists the other sinks in its
+	// MergedSinks (optional)
+	DedupeSources bool
+	// Autofix returns a suggested fix for a finding at the given sink call,
+	// or "" when the call cannot be rewritten safely (optional)
+	Autofix func(pass *analysis.Pass, call *ast.CallExpr) string
+	// Classify returns the subcategory of a finding at the given call to
+	// sink and a detail added to the rule's description, or "" for a finding
+	// of the rule's general kind (optional)
+	Classify func(pass *analysis.Pass, call *ast.CallExpr, sink Sink) (subcategory, detail string)
 }
 
-// Analyzer performs taint analysis on SSA programs.
 // paramKey identifies a specific parameter of a function for memoization.
 type paramKey struct {
 	fn       *ssa.Function
 	paramIdx int
 }
 
+// taintCache records the parameters found to be tainted. It is shared by the
+// workers of an analysis; a nil cache records nothing.
+type taintCache struct {
+	mu      sync.RWMutex
+	tainted map[paramKey]bool
+}
+
+func newTaintCache() *taintCache {
+	return &taintCache{tainted: make(map[paramKey]bool)}
+}
+
+// has reports whether key was found to be tainted.
+func (c *taintCache) has(key paramKey) bool {
+	if c == nil {
+		return false
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.tainted[key]
+}
+
+// add records key as tainted.
+func (c *taintCache) add(key paramKey) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.tainted[key] = true
+}
+
+// summaryKey identifies a function together with the indexes of its
+// parameters that receive tainted arguments.
+type summaryKey struct {
+	fn     *ssa.Function
+	params string
+}
+
+// summaryCache records, per function and set of tainted parameters, whether
+// the taint reaches the function's return values. Like taintCache, it is
+// shared by the workers of an analysis and a nil cache records nothing.
+type summaryCache struct {
+	mu    sync.RWMutex
+	flows map[summaryKey]bool
+}
+
+func newSummaryCache() *summaryCache {
+	return &summaryCache{flows: make(map[summaryKey]bool)}
+}
+
+// lookup returns the recorded summary for key, if any.
+func (c *summaryCache) lookup(key summaryKey) (flows bool, ok bool) {
+	if c == nil {
+		return false, false
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	flows, ok = c.flows[key]
+	return flows, ok
+}
+
+// store records the summary for key.
+func (c *summaryCache) store(key summaryKey, flows bool) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.flows[key] = flows
+}
+
+// Analyzer performs taint analysis on SSA programs.
 type Analyzer struct {
 	config          *Config
-	sources         map[string]Source   // keyed by full type string
-	funcSrcs        map[string]Source   // function sources keyed by "pkg.Func"
-	sinks           map[string]Sink     // keyed by full function string
-	sanitizers      map[string]struct{} // keyed by full function string
+	sources         map[string]Source    // keyed by full type string
+	funcSrcs        map[string]Source    // function sources keyed by "pkg.Func"
+	sinks           map[string]Sink      // keyed by full function string
+	sanitizers      map[string]Sanitizer // keyed by full function string
 	callGraph       *callgraph.Graph
-	prog            *ssa.Program      // set at Analyze time for ArgTypeGuards resolution
-	paramTaintCache map[paramKey]bool // caches true results from isParameterTainted
+	prog            *ssa.Program                 // set at Analyze time for ArgTypeGuards resolution
+	paramTaintCache *taintCache                  // caches true results from isParameterTainted
+	summaries       *summaryCache                // caches whether tainted params of a function reach its return
+	globalStores    map[globalField][]*ssa.Store // stores to package-level variables, set at Analyze time
+	syncMapStores   map[any][]*ssa.Call          // calls storing values in a sync.Map, set at Analyze time
+	fixedMaps       map[*ssa.Global]bool         // package-level maps that are fixed allowlists, set at Analyze time
+	constants       *constants                   // provably constant values, set at Analyze time
+	trail           []ssa.Value                  // values on the current isTainted search path
+	flow            []ssa.Value                  // search path of the last value found to be tainted
+	widened         map[ssa.Value]string         // values assumed tainted on the current search, with the reason
+	graphs          []*funcGraph                 // taint graphs recorded when config.GraphDir is set
+	callDepth       int                          // calls followed on the current isTainted search path
+	workers         int                          // goroutines analyzing functions concurrently
+	deadline        time.Time                    // end of the time allowed for the current function, zero if unlimited
+	steps           int                          // isTainted calls since the current function started
+	aborted         bool                         // the current function exceeded its deadline
+	timedOut        []*ssa.Function              // functions skipped by the last Analyze for exceeding config.FunctionTimeout
+}
+
+// deadlineCheckInterval is the number of isTainted calls between two checks
+// of the deadline, so that the clock is not read on every step.
+const deadlineCheckInterval = 1024
+
+// globalField identifies a package-level variable, or one of its fields when
+// field is not wholeGlobal.
+type globalField struct {
+	global *ssa.Global
+	field  int
 }
 
+// wholeGlobal marks stores to a package-level variable as a whole.
+const wholeGlobal = -1
+
 // SetCallGraph injects a precomputed call graph.
 func (a *Analyzer) SetCallGraph(cg *callgraph.Graph) {
 	a.callGraph = cg
 }
 
+// SetWorkers sets the number of goroutines that analyze functions
+// concurrently. It defaults to GOMAXPROCS; values below 1 mean 1.
+func (a *Analyzer) SetWorkers(n int) {
+	a.workers = max(n, 1)
+}
+
 // New creates a new taint analyzer with the given configuration.
+// More sources, sinks and sanitizers can be registered before Analyze runs.
 func New(config *Config) *Analyzer {
 	a := &Analyzer{
 		config:     config,
 		sources:    make(map[string]Source),
 		funcSrcs:   make(map[string]Source),
 		sinks:      make(map[string]Sink),
-		sanitizers: make(map[string]struct{}),
+		sanitizers: make(map[string]Sanitizer),
+		workers:    runtime.GOMAXPROCS(0),
 	}
 
-	// Index sources for fast lookup, separating type sources from function sources
+	// Index sources, sinks and sanitizers for fast lookup
 	for _, src := range config.Sources {
-		key := formatSourceKey(src)
-		a.sources[key] = src
-		if src.IsFunc {
-			a.funcSrcs[key] = src
-		}
+		a.AddSource(src)
 	}
-
-	// Index sinks for fast lookup
 	for _, sink := range config.Sinks {
-		key := formatSinkKey(sink)
-		a.sinks[key] = sink
+		a.AddSink(sink)
 	}
-
-	// Index sanitizers for fast lookup
 	for _, san := range config.Sanitizers {
-		key := formatSanitizerKey(san)
-		a.sanitizers[key] = struct{}{}
+		a.AddSanitizer(san)
 	}
 
 	return a
 }
 
+// AddSource registers a source of tainted data, replacing a source with the
+// same package, receiver and name.
+func (a *Analyzer) AddSource(src Source) {
+	key := formatSourceKey(src)
+	a.sources[key] = src
+	if src.IsFunc {
+		a.funcSrcs[key] = src
+	} else {
+		delete(a.funcSrcs, key)
+	}
+}
+
+// AddSink registers a function or method that must not receive tainted data,
+// replacing a sink with the same package, receiver and method.
+func (a *Analyzer) AddSink(sink Sink) {
+	a.sinks[formatSinkKey(sink)] = sink
+}
+
+// AddSanitizer registers a function or method whose result is not tainted,
+// replacing a sanitizer with the same package, receiver and method.
+func (a *Analyzer) AddSanitizer(san Sanitizer) {
+	a.sanitizers[formatSanitizerKey(san)] = san
+}
+
 // formatSourceKey creates a lookup key for a source.
 func formatSourceKey(src Source) string {
+	if src.Receiver != "" {
+		recv := src.Package + "." + src.Receiver
+		if src.Pointer {
+			recv = "*" + recv
+		}
+		return "(" + recv + ")." + src.Name
+	}
 	key := src.Package + "." + src.Name
 	if src.Pointer {
 		key = "*" + key
@@ -322,20 +667,229 @@ func (a *Analyzer) Analyze(prog *ssa.Program, srcFuncs []*ssa.Function) []Result
 		a.callGraph = cha.CallGraph(prog)
 	}
 
-	a.paramTaintCache = make(map[paramKey]bool)
+	a.paramTaintCache = newTaintCache()
+	a.summaries = newSummaryCache()
+	a.globalStores = indexGlobalStores(srcFuncs)
+	a.syncMapStores = indexSyncMapStores(srcFuncs)
+	a.fixedMaps = indexFixedMaps(srcFuncs)
+	a.constants = a.markConstants(srcFuncs)
+	a.graphs = nil
+	a.timedOut = nil
+
+	// Find all sink calls in the program. Functions are analyzed by a pool of
+	// workers; the results are kept in the order of srcFuncs so that they do
+	// not depend on the number of workers.
+	excluded := a.excludedFuncs(srcFuncs)
+	funcResults := make([][]Result, len(srcFuncs))
+	aborted := make([]bool, len(srcFuncs))
+	forks := make([]*Analyzer, min(a.workers, len(srcFuncs)))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for i := range forks {
+		forks[i] = a.fork()
+		wg.Add(1)
+		go func(worker *Analyzer) {
+			defer wg.Done()
+			for j := range jobs {
+				if excluded[j] {
+					continue
+				}
+				funcResults[j], aborted[j] = worker.analyzeFunction(srcFuncs[j])
+			}
+		}(forks[i])
+	}
+	for j := range srcFuncs {
+		jobs <- j
+	}
+	close(jobs)
+	wg.Wait()
 
 	var results []Result
-
-	// Find all sink calls in the program
-	for _, fn := range srcFuncs {
-		results = append(results, a.analyzeFunctionSinks(fn)...)
+	for j, r := range funcResults {
+		results = append(results, r...)
+		if aborted[j] {
+			a.timedOut = append(a.timedOut, srcFuncs[j])
+		}
+	}
+	for _, worker := range forks {
+		a.graphs = append(a.graphs, worker.graphs...)
 	}
 
 	a.paramTaintCache = nil
+	a.summaries = nil
+	a.globalStores = nil
+	a.syncMapStores = nil
+	a.fixedMaps = nil
+	a.constants = nil
 
+	if a.config.DedupeSources {
+		results = dedupeSources(results)
+	}
 	return results
 }
 
+// flowSource identifies the source of a flow by the position and SSA form of
+// its source step.
+type flowSource struct {
+	pos         token.Pos
+	description string
+}
+
+// sourceStep returns the step of flow where the tainted data is read: its
+// first step that is not a parameter, such as the r.FormValue("name") call
+// on a request parameter, or its last step when all of them are. It matches
+// (*issue.Issue).SourceStep, which #nosec annotations are looked up on.
+func sourceStep(flow []FlowStep) FlowStep {
+	for _, step := range flow {
+		if step.Kind != issue.FlowKindParameter {
+			return step
+		}
+	}
+	return flow[len(flow)-1]
+}
+
+// dedupeSources keeps, among the results whose flows start at the same
+// source, the one with the earliest sink, and lists the sinks of the others
+// in its MergedSinks. Results without a flow are all kept.
+func dedupeSources(results []Result) []Result {
+	kept := make(map[flowSource]int)
+	var deduped []Result
+	for _, r := range results {
+		if len(r.Flow) == 0 {
+			deduped = append(deduped, r)
+			continue
+		}
+		step := sourceStep(r.Flow)
+		key := flowSource{pos: step.Pos, description: step.Description}
+		i, ok := kept[key]
+		if !ok {
+			kept[key] = len(deduped)
+			deduped = append(deduped, r)
+			continue
+		}
+		first := &deduped[i]
+		if r.SinkPos < first.SinkPos {
+			// r becomes the reported result and takes over the merged sinks
+			r.MergedSinks, first.MergedSinks = first.MergedSinks, nil
+			r, *first = *first, r
+		}
+		first.MergedSinks = append(first.MergedSinks, r.Flow[len(r.Flow)-1])
+	}
+	for i := range deduped {
+		slices.SortFunc(deduped[i].MergedSinks, func(x, y FlowStep) int {
+			return cmp.Compare(x.Pos, y.Pos)
+		})
+	}
+	return deduped
+}
+
+// TimedOut returns the functions whose sinks the last Analyze did not check
+// because their analysis exceeded the configured FunctionTimeout.
+func (a *Analyzer) TimedOut() []*ssa.Function {
+	return a.timedOut
+}
+
+// analyzeFunction checks the sinks of fn within the configured
+// FunctionTimeout. It reports whether the analysis was aborted, in which case
+// the partial results are dropped.
+func (a *Analyzer) analyzeFunction(fn *ssa.Function) ([]Result, bool) {
+	if a.config.FunctionTimeout <= 0 {
+		return a.analyzeFunctionSinks(fn), false
+	}
+	a.deadline = time.Now().Add(a.config.FunctionTimeout)
+	a.steps = 0
+	a.aborted = false
+	results := a.analyzeFunctionSinks(fn)
+	aborted := a.aborted
+	a.deadline = time.Time{}
+	a.aborted = false
+	if aborted {
+		return nil, true
+	}
+	return results, false
+}
+
+// pastDeadline reports whether the analysis of the current function exceeded
+// its deadline. Once it has, every taint search of the function ends at once.
+func (a *Analyzer) pastDeadline() bool {
+	if a.aborted {
+		return true
+	}
+	if a.deadline.IsZero() {
+		return false
+	}
+	a.steps++
+	if a.steps%deadlineCheckInterval == 0 && time.Now().After(a.deadline) {
+		a.aborted = true
+	}
+	return a.aborted
+}
+
+// excludedFuncs reports, for each of funcs, whether it is declared in a file
+// matching one of the configured ExcludePaths.
+func (a *Analyzer) excludedFuncs(funcs []*ssa.Function) []bool {
+	excluded := make([]bool, len(funcs))
+	if len(a.config.ExcludePaths) == 0 {
+		return excluded
+	}
+	files := make(map[string]bool)
+	for i, fn := range funcs {
+		if !fn.Pos().IsValid() {
+			continue
+		}
+		filename := fn.Prog.Fset.Position(fn.Pos()).Filename
+		match, ok := files[filename]
+		if !ok {
+			match = slices.ContainsFunc(a.config.ExcludePaths, func(pattern string) bool {
+				return matchesPath(pattern, filename)
+			})
+			files[filename] = match
+		}
+		excluded[i] = match
+	}
+	return excluded
+}
+
+// fork returns a copy of a for a worker of Analyze. The configuration, the
+// indexes, the call graph and the caches are shared and only read or
+// updated under a lock; the state of a taint search is the worker's own.
+func (a *Analyzer) fork() *Analyzer {
+	worker := *a
+	worker.trail = nil
+	worker.flow = nil
+	worker.widened = nil
+	worker.graphs = nil
+	worker.callDepth = 0
+	return &worker
+}
+
+// indexGlobalStores records every store to a package-level variable, or to a
+// field of one, made by the given functions.
+func indexGlobalStores(funcs []*ssa.Function) map[globalField][]*ssa.Store {
+	stores := make(map[globalField][]*ssa.Store)
+	for _, fn := range funcs {
+		for _, block := range fn.Blocks {
+			for _, instr := range block.Instrs {
+				store, ok := instr.(*ssa.Store)
+				if !ok {
+					continue
+				}
+				switch addr := store.Addr.(type) {
+				case *ssa.Global:
+					key := globalField{global: addr, field: wholeGlobal}
+					stores[key] = append(stores[key], store)
+				case *ssa.FieldAddr:
+					if global, ok := addr.X.(*ssa.Global); ok {
+						key := globalField{global: global, field: addr.Field}
+						stores[key] = append(stores[key], store)
+					}
+				}
+			}
+		}
+	}
+	return stores
+}
+
 // analyzeFunctionSinks finds sink calls in a function and traces taint.
 func (a *Analyzer) analyzeFunctionSinks(fn *ssa.Function) []Result {
 	if fn == nil || fn.Blocks == nil {
@@ -343,70 +897,128 @@ func (a *Analyzer) analyzeFunctionSinks(fn *ssa.Function) []Result {
 	}
 
 	var results []Result
+	var graph *funcGraph
+	if a.config.GraphDir != "" {
+		graph = &funcGraph{fn: fn}
+	}
 
 	for _, block := range fn.Blocks {
 		for _, instr := range block.Instrs {
-			call, ok := instr.(*ssa.Call)
-			if !ok {
-				continue
-			}
+			var sink Sink
+			var argsToCheck []ssa.Value
 
-			// Check if this call is a sink
-			sink, isSink := a.isSinkCall(call)
-			if !isSink {
-				continue
-			}
+			switch instr := instr.(type) {
+			case ssa.CallInstruction:
+				// Deferred and go calls receive their arguments at the defer or
+				// go statement, so they are checked like plain calls
+				common := instr.Common()
 
-			// Apply ArgTypeGuards: skip this sink if argument type constraints
-			// are not satisfied (e.g. writer is not http.ResponseWriter).
-			if !guardsSatisfied(call.Call.Args, sink, a.prog) {
-				continue
-			}
+				// Check if this call is a sink
+				var isSink bool
+				if sink, isSink = a.isSinkCall(common); !isSink {
+					continue
+				}
+				if graph != nil {
+					graph.sinks = append(graph.sinks, instr)
+				}
 
-			// Determine which arguments to check for taint
-			var argsToCheck []ssa.Value
+				// Apply ArgTypeGuards and ArgValues: skip this sink if argument
+				// constraints are not satisfied (e.g. writer is not
+				// http.ResponseWriter, or the header set is not Location).
+				if !guardsSatisfied(common.Args, sink, a.prog) || !argValuesMatch(common.Args, sink) {
+					continue
+				}
 
-			if len(sink.CheckArgs) > 0 {
-				// Sink specifies which argument positions to check
-				for _, idx := range sink.CheckArgs {
-					if idx < len(call.Call.Args) {
-						argsToCheck = append(argsToCheck, call.Call.Args[idx])
+				// Determine which arguments to check for taint
+				if len(sink.CheckArgs) > 0 {
+					// Sink specifies which argument positions to check
+					for _, idx := range sink.CheckArgs {
+						if idx < len(common.Args) {
+							argsToCheck = append(argsToCheck, common.Args[idx])
+						}
+					}
+				} else {
+					// No CheckArgs specified: check all arguments
+					argsToCheck = common.Args
+				}
+				if len(sink.DocumentKeys) > 0 {
+					var parts []ssa.Value
+					for _, arg := range argsToCheck {
+						parts = append(parts, documentValues(arg, sink.DocumentKeys)...)
 					}
+					argsToCheck = parts
+				}
+
+			case *ssa.ChangeType, *ssa.Convert:
+				// Conversion to a sink type, e.g. template.HTML(v)
+				var converted ssa.Value
+				var isSink bool
+				if sink, converted, isSink = a.isSinkConversion(instr); !isSink {
+					continue
 				}
-			} else {
-				// No CheckArgs specified: check all arguments
-				argsToCheck = call.Call.Args
+				if graph != nil {
+					graph.sinks = append(graph.sinks, instr)
+				}
+				argsToCheck = []ssa.Value{converted}
+
+			case *ssa.Store:
+				// Store to a sink field, e.g. cmd.Env = env
+				var stored ssa.Value
+				var isSink bool
+				if sink, stored, isSink = a.isSinkStore(instr); !isSink {
+					continue
+				}
+				if graph != nil {
+					graph.sinks = append(graph.sinks, instr)
+				}
+				argsToCheck = []ssa.Value{stored}
+
+			default:
+				continue
 			}
 
 			// Check if any of the specified arguments are tainted
 			for _, arg := range argsToCheck {
-				if a.isTainted(arg, fn, make(map[ssa.Value]bool), 0) {
+				a.flow = nil
+				a.widened = nil
+				if a.isTaintedAt(arg, block, fn, make(map[ssa.Value]bool), 0) {
+					approximations := a.approximations(a.flow)
 					results = append(results, Result{
-						Sink:    sink,
-						SinkPos: call.Pos(),
-						Path:    a.buildPath(fn),
+						Sink:           sink,
+						SinkPos:        instr.Pos(),
+						Path:           a.buildPath(fn),
+						Flow:           buildFlow(a.flow, instr),
+						Approximate:    len(approximations) > 0,
+						Approximations: approximations,
 					})
+					if graph != nil {
+						graph.flows = append(graph.flows, sinkFlow{sink: instr, path: a.flow})
+					}
 					break
 				}
 			}
 		}
 	}
 
+	if graph != nil && len(graph.sinks) > 0 {
+		a.graphs = append(a.graphs, graph)
+	}
+
 	return results
 }
 
 // isSinkCall checks if a call instruction is a sink and returns the sink info.
-func (a *Analyzer) isSinkCall(call *ssa.Call) (Sink, bool) {
+func (a *Analyzer) isSinkCall(call *ssa.CallCommon) (Sink, bool) {
 	// Try to get receiver info first (works for both concrete and interface calls)
 	var pkg, receiverName, methodName string
 	var isPointer bool
 
 	// Check for method call (invoke or static with receiver)
-	if call.Call.IsInvoke() {
+	if call.IsInvoke() {
 		// Interface method call - receiver is in Call.Value, not Args
-		if call.Call.Value != nil {
-			recvType := call.Call.Value.Type()
-			methodName = call.Call.Method.Name()
+		if call.Value != nil {
+			recvType := call.Value.Type()
+			methodName = call.Method.Name()
 
 			// For interface calls, the type is usually a Named type pointing to the interface
 			if named, ok := recvType.(*types.Named); ok {
@@ -418,7 +1030,7 @@ func (a *Analyzer) isSinkCall(call *ssa.Call) (Sink, bool) {
 
 			// Match against sinks (interface methods don't have Pointer field usually)
 			for _, sink := range a.sinks {
-				if sink.Package == pkg && sink.Receiver == receiverName && sink.Method == methodName {
+				if !sink.Conversion && !sink.Field && sink.Package == pkg && sink.Receiver == receiverName && sink.Method == methodName {
 					return sink, true
 				}
 			}
@@ -426,7 +1038,7 @@ func (a *Analyzer) isSinkCall(call *ssa.Call) (Sink, bool) {
 	}
 
 	// Try static callee (for non-interface method calls and functions)
-	callee := call.Call.StaticCallee()
+	callee := call.StaticCallee()
 	if callee != nil {
 		if callee.Pkg != nil && callee.Pkg.Pkg != nil {
 			pkg = callee.Pkg.Pkg.Path()
@@ -451,7 +1063,7 @@ func (a *Analyzer) isSinkCall(call *ssa.Call) (Sink, bool) {
 	// Match against configured sinks
 	for _, sink := range a.sinks {
 		// Package must match
-		if sink.Package != pkg {
+		if sink.Package != pkg || sink.Conversion || sink.Field {
 			continue
 		}
 
@@ -471,6 +1083,60 @@ func (a *Analyzer) isSinkCall(call *ssa.Call) (Sink, bool) {
 	return Sink{}, false
 }
 
+// isSinkConversion checks if instr converts a value to a type configured as
+// a conversion sink, and returns the sink and the converted value.
+func (a *Analyzer) isSinkConversion(instr ssa.Instruction) (Sink, ssa.Value, bool) {
+	var converted ssa.Value
+	var target types.Type
+	switch conv := instr.(type) {
+	case *ssa.ChangeType:
+		converted, target = conv.X, conv.Type()
+	case *ssa.Convert:
+		converted, target = conv.X, conv.Type()
+	default:
+		return Sink{}, nil, false
+	}
+	named, ok := types.Unalias(target).(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return Sink{}, nil, false
+	}
+	sink, ok := a.sinks[formatSinkKey(Sink{Package: named.Obj().Pkg().Path(), Method: named.Obj().Name()})]
+	if !ok || !sink.Conversion {
+		return Sink{}, nil, false
+	}
+	return sink, converted, true
+}
+
+// isSinkStore checks if store writes a value to a struct field configured as
+// a sink. It returns the sink and the stored value.
+func (a *Analyzer) isSinkStore(store *ssa.Store) (Sink, ssa.Value, bool) {
+	addr, ok := store.Addr.(*ssa.FieldAddr)
+	if !ok {
+		return Sink{}, nil, false
+	}
+	ptr, ok := addr.X.Type().Underlying().(*types.Pointer)
+	if !ok {
+		return Sink{}, nil, false
+	}
+	named, ok := types.Unalias(ptr.Elem()).(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return Sink{}, nil, false
+	}
+	st, ok := named.Underlying().(*types.Struct)
+	if !ok || addr.Field >= st.NumFields() {
+		return Sink{}, nil, false
+	}
+	sink, ok := a.sinks[formatSinkKey(Sink{
+		Package:  named.Obj().Pkg().Path(),
+		Receiver: named.Obj().Name(),
+		Method:   st.Field(addr.Field).Name(),
+	})]
+	if !ok || !sink.Field {
+		return Sink{}, nil, false
+	}
+	return sink, store.Val, true
+}
+
 // isSanitizerCall checks if a call instruction is a sanitizer.
 func (a *Analyzer) isSanitizerCall(call *ssa.Call) bool {
 	if len(a.sanitizers) == 0 {
@@ -510,18 +1176,190 @@ func (a *Analyzer) isSanitizerCall(call *ssa.Call) bool {
 		Method:   methodName,
 		Pointer:  isPointer,
 	})
-	_, found := a.sanitizers[key]
-	return found
+	san, found := a.sanitizers[key]
+	if !found {
+		return false
+	}
+	return len(san.Strips) == 0 || stripsAll(call, san.Strips)
+}
+
+// stripsAll reports whether call, together with the calls to the same
+// function it is applied to, replaces every substring in strips by a constant
+// that contains none of them.
+func stripsAll(call *ssa.Call, strips []string) bool {
+	callee := call.Call.StaticCallee()
+	stripped := make(map[string]bool)
+	for call != nil && call.Call.StaticCallee() == callee && len(call.Call.Args) >= 3 {
+		old, ok := stringConst(call.Call.Args[1])
+		if !ok || !slices.Contains(strips, old) {
+			break
+		}
+		replacement, ok := stringConst(call.Call.Args[2])
+		if !ok || slices.ContainsFunc(strips, func(s string) bool { return strings.Contains(replacement, s) }) {
+			break
+		}
+		stripped[old] = true
+		call, _ = call.Call.Args[0].(*ssa.Call)
+	}
+	return len(stripped) == len(strips)
+}
+
+// stringConst returns the value of v if it is a string constant.
+func stringConst(v ssa.Value) (string, bool) {
+	c, ok := v.(*ssa.Const)
+	if !ok || c.Value == nil || c.Value.Kind() != constant.String {
+		return "", false
+	}
+	return constant.StringVal(c.Value), true
+}
+
+// approximations returns the values of the search path of a tainted sink
+// argument that were found tainted by an over-approximation, in flow order.
+func (a *Analyzer) approximations(path []ssa.Value) []FlowStep {
+	var steps []FlowStep
+	for i := len(path) - 1; i >= 0; i-- {
+		v := path[i]
+		var reason string
+		switch val := v.(type) {
+		case *ssa.MakeMap:
+			reason = "map tainted as a whole by one of its values"
+		case *ssa.MakeChan:
+			reason = "channel tainted as a whole by one of its values"
+		case *ssa.MakeSlice:
+			reason = "slice tainted as a whole by one of its elements"
+		case *ssa.Call:
+			if callee := val.Call.StaticCallee(); callee != nil && syncMapLoads[callee.String()] {
+				reason = "sync.Map tainted as a whole by one of its values"
+				if callee.String() == "(*sync.Pool).Get" {
+					reason = "sync.Pool tainted as a whole by one of its values"
+				}
+			}
+			if callee := val.Call.StaticCallee(); callee != nil && marshalFuncs[callee.String()] {
+				reason = "encoding tainted as a whole by one of its fields"
+			}
+		}
+		if widened, ok := a.widened[v]; ok {
+			reason = widened
+		}
+		if reason != "" {
+			steps = append(steps, FlowStep{Pos: v.Pos(), Description: reason})
+		}
+	}
+	return steps
+}
+
+// widen records that v is assumed tainted on the current search for the
+// given reason rather than because a source was found.
+func (a *Analyzer) widen(v ssa.Value, reason string) {
+	if a.widened == nil {
+		a.widened = make(map[ssa.Value]string)
+	}
+	a.widened[v] = reason
+}
+
+// buildFlow converts the search path of a tainted sink argument, which runs
+// from the argument back to the source, into flow steps from the source to the
+// sink call. Values without a position, such as phi nodes, are left out.
+func buildFlow(path []ssa.Value, sink ssa.Instruction) []FlowStep {
+	var steps []FlowStep
+	for i := len(path) - 1; i >= 0; i-- {
+		v := path[i]
+		if !v.Pos().IsValid() || (len(steps) > 0 && steps[len(steps)-1].Pos == v.Pos()) {
+			continue
+		}
+		steps = append(steps, FlowStep{Pos: v.Pos(), Description: describeValue(v), Kind: flowKind(v)})
+	}
+	return append(steps, FlowStep{Pos: sink.Pos(), Description: describeSink(sink), Kind: issue.FlowKindCall})
+}
+
+// flowKind returns the SSA category of a flow step value.
+func flowKind(v ssa.Value) string {
+	switch val := v.(type) {
+	case *ssa.Call:
+		return issue.FlowKindCall
+	case *ssa.Parameter:
+		return issue.FlowKindParameter
+	case *ssa.FreeVar:
+		return issue.FlowKindCapture
+	case *ssa.Field, *ssa.FieldAddr:
+		return issue.FlowKindField
+	case *ssa.Index, *ssa.IndexAddr, *ssa.Lookup:
+		return issue.FlowKindIndex
+	case *ssa.Slice:
+		return issue.FlowKindSlice
+	case *ssa.Convert, *ssa.ChangeType, *ssa.ChangeInterface, *ssa.MakeInterface, *ssa.TypeAssert:
+		return issue.FlowKindConvert
+	case *ssa.BinOp:
+		return issue.FlowKindOperation
+	case *ssa.UnOp:
+		switch val.Op {
+		case token.MUL:
+			return issue.FlowKindLoad
+		case token.ARROW:
+			return issue.FlowKindReceive
+		}
+		return issue.FlowKindOperation
+	case *ssa.Extract:
+		return issue.FlowKindExtract
+	case *ssa.Alloc, *ssa.Phi:
+		return issue.FlowKindAssign
+	case *ssa.Global:
+		return issue.FlowKindGlobal
+	case *ssa.MakeMap, *ssa.MakeChan, *ssa.MakeSlice:
+		return issue.FlowKindContainer
+	}
+	return issue.FlowKindValue
+}
+
+// describeSink returns a short SSA description of a sink instruction, which
+// is not a value when it is a deferred call or a go statement.
+func describeSink(sink ssa.Instruction) string {
+	if v, ok := sink.(ssa.Value); ok {
+		return describeValue(v)
+	}
+	return sink.String()
+}
+
+// describeValue returns a short SSA description of v, naming the register
+// that an instruction defines so that consecutive steps can be followed.
+func describeValue(v ssa.Value) string {
+	if _, ok := v.(ssa.Instruction); ok {
+		return v.Name() + " = " + v.String()
+	}
+	return v.String()
 }
 
 // isTainted recursively checks if a value is tainted (originates from a source).
+// The chain of values searched to reach a source is kept in a.flow.
+func (a *Analyzer) isTainted(v ssa.Value, fn *ssa.Function, visited map[ssa.Value]bool, depth int) bool {
+	if a.pastDeadline() {
+		return false
+	}
+	a.trail = append(a.trail, v)
+	tainted := a.traceTaint(v, fn, visited, depth)
+	// The innermost tainted value records the path. A path recorded under a
+	// value that turned out not to be tainted, as when a tainted argument does
+	// not flow to a callee's return, is replaced by the next one found.
+	if tainted && !hasPrefix(a.flow, a.trail) {
+		a.flow = slices.Clone(a.trail)
+	}
+	a.trail = a.trail[:len(a.trail)-1]
+	return tainted
+}
+
+// hasPrefix reports whether path starts with prefix.
+func hasPrefix(path, prefix []ssa.Value) bool {
+	return len(path) >= len(prefix) && slices.Equal(path[:len(prefix)], prefix)
+}
+
+// traceTaint implements isTainted.
 //
 // KEY DESIGN PRINCIPLE: Type-based source matching is ONLY applied to function
 // parameters received from external callers and global variables. Locally
 // constructed values of source types (e.g., http.NewRequest with a hardcoded
 // URL) are NOT automatically considered tainted — their taintedness depends
 // on whether the data flowing into them is tainted.
-func (a *Analyzer) isTainted(v ssa.Value, fn *ssa.Function, visited map[ssa.Value]bool, depth int) bool {
+func (a *Analyzer) traceTaint(v ssa.Value, fn *ssa.Function, visited map[ssa.Value]bool, depth int) bool {
 	if v == nil {
 		return false
 	}
@@ -538,8 +1376,9 @@ func (a *Analyzer) isTainted(v ssa.Value, fn *ssa.Function, visited map[ssa.Valu
 	visited[v] = true
 
 	// Constants are compile-time literals and can never carry attacker-controlled
-	// data. Short-circuit immediately — no taint possible.
-	if _, ok := v.(*ssa.Const); ok {
+	// data, nor can values computed only from them. Short-circuit immediately —
+	// no taint possible.
+	if a.constants.isConstant(v) {
 		return false
 	}
 
@@ -549,6 +1388,10 @@ func (a *Analyzer) isTainted(v ssa.Value, fn *ssa.Function, visited map[ssa.Valu
 		// Parameters are tainted if:
 		// 1. Their type matches a source type AND they come from an external caller
 		// 2. A caller passes tainted data to this parameter position
+		// 3. They point to a struct that a setter fills with tainted data
+		if _, ok := val.Type().Underlying().(*types.Pointer); ok && a.isSetterTainted(val, anyField, fn, visited, depth+1) {
+			return true
+		}
 		return a.isParameterTainted(val, fn, visited, depth+1)
 
 	case *ssa.Call:
@@ -562,6 +1405,21 @@ func (a *Analyzer) isTainted(v ssa.Value, fn *ssa.Function, visited map[ssa.Valu
 			return true
 		}
 
+		// Formatted text is tainted if any formatted argument is, whatever the verb
+		if callee := val.Call.StaticCallee(); callee != nil && formatFuncs[callee.String()] {
+			return a.isFormatCallTainted(val, fn, visited, depth+1)
+		}
+
+		// A value loaded from a sync.Map is tainted if any stored value is
+		if callee := val.Call.StaticCallee(); callee != nil && syncMapLoads[callee.String()] {
+			return a.isSyncMapTainted(val, visited, depth+1)
+		}
+
+		// The encoding of a struct is tainted if any of its fields is
+		if callee := val.Call.StaticCallee(); callee != nil && marshalFuncs[callee.String()] {
+			return a.isMarshalTainted(val, fn, visited, depth+1)
+		}
+
 		// For method calls, check if the receiver carries taint.
 		// This handles patterns like: req.URL.Query().Get("param")
 		// where req is a tainted *http.Request parameter.
@@ -605,6 +1463,13 @@ func (a *Analyzer) isTainted(v ssa.Value, fn *ssa.Function, visited map[ssa.Valu
 			}
 		}
 
+		// Bound method value, e.g. query := f.Query; query(). The receiver is a
+		// free variable of the closure rather than an argument, but carries
+		// taint to the result like the receiver of a direct call.
+		if recv, ok := boundReceiver(val.Call.Value); ok && a.isTainted(recv, fn, visited, depth+1) {
+			return true
+		}
+
 		// For non-method calls (plain functions), check if data-carrying arguments
 		// are tainted AND actually flow to the return value.
 		if callee := val.Call.StaticCallee(); callee != nil {
@@ -657,7 +1522,7 @@ func (a *Analyzer) isTainted(v ssa.Value, fn *ssa.Function, visited map[ssa.Valu
 
 	case *ssa.BinOp:
 		// Binary operation - tainted if either operand is tainted
-		return a.isTainted(val.X, fn, visited, depth+1) || a.isTainted(val.Y, fn, visited, depth+1)
+		return a.isTaintedAt(val.X, val.Block(), fn, visited, depth+1) || a.isTaintedAt(val.Y, val.Block(), fn, visited, depth+1)
 
 	case *ssa.Phi:
 		// Phi node - tainted if any edge is tainted
@@ -672,7 +1537,11 @@ func (a *Analyzer) isTainted(v ssa.Value, fn *ssa.Function, visited map[ssa.Valu
 		return a.isTainted(val.Tuple, fn, visited, depth+1)
 
 	case *ssa.TypeAssert:
-		// Type assertion - check the underlying value
+		// Type assertion - check the underlying value, and writes into a
+		// buffer asserted from an interface, e.g. one got from a sync.Pool
+		if a.isBufferWriteTainted(val, fn, visited, depth+1) {
+			return true
+		}
 		return a.isTainted(val.X, fn, visited, depth+1)
 
 	case *ssa.MakeInterface:
@@ -680,10 +1549,25 @@ func (a *Analyzer) isTainted(v ssa.Value, fn *ssa.Function, visited map[ssa.Valu
 		return a.isTainted(val.X, fn, visited, depth+1)
 
 	case *ssa.Slice:
-		// Slice operation - check the sliced value
-		return a.isTainted(val.X, fn, visited, depth+1)
-
-	case *ssa.Convert:
+		// Slice operation - check the sliced value, and element assignments made
+		// through the slice itself (make with a constant length is a sliced array)
+		if a.isCopyTainted(val, fn, visited, depth+1) {
+			return true
+		}
+		if refs := val.Referrers(); refs != nil {
+			for _, ref := range *refs {
+				if indexAddr, ok := ref.(*ssa.IndexAddr); ok {
+					for _, v := range storesTo(indexAddr) {
+						if a.isTainted(v, fn, visited, depth+1) {
+							return true
+						}
+					}
+				}
+			}
+		}
+		return a.isTainted(val.X, fn, visited, depth+1)
+
+	case *ssa.Convert:
 		// Type conversion - check the converted value
 		return a.isTainted(val.X, fn, visited, depth+1)
 
@@ -691,8 +1575,25 @@ func (a *Analyzer) isTainted(v ssa.Value, fn *ssa.Function, visited map[ssa.Valu
 		// Type change - check the underlying value
 		return a.isTainted(val.X, fn, visited, depth+1)
 
+	case *ssa.ChangeInterface:
+		// Interface change, e.g. an io.ReadCloser passed as an io.Reader
+		return a.isTainted(val.X, fn, visited, depth+1)
+
 	case *ssa.Alloc:
 		// Allocation - check referrers for assignments
+		if a.isBufferWriteTainted(val, fn, visited, depth+1) {
+			return true
+		}
+		// Setter methods storing a tainted argument in a field, e.g. qb.SetTable(v)
+		if a.isSetterTainted(val, anyField, fn, visited, depth+1) {
+			return true
+		}
+		if a.isCopyTainted(val, fn, visited, depth+1) {
+			return true
+		}
+		if a.isDecodeTainted(val, fn, visited, depth+1) {
+			return true
+		}
 		for _, ref := range *val.Referrers() {
 			// Direct stores to the allocation
 			if store, ok := ref.(*ssa.Store); ok {
@@ -700,12 +1601,16 @@ func (a *Analyzer) isTainted(v ssa.Value, fn *ssa.Function, visited map[ssa.Valu
 					return true
 				}
 			}
+			// Source functions that fill an out-parameter (e.g., flag.StringVar(&v, ...))
+			if call, ok := ref.(*ssa.Call); ok && a.isSourceFuncCall(call) {
+				return true
+			}
 			// For arrays/slices, check stores to indexed addresses (e.g., varargs)
 			if indexAddr, ok := ref.(*ssa.IndexAddr); ok {
 				if indexRefs := indexAddr.Referrers(); indexRefs != nil {
 					for _, indexRef := range *indexRefs {
 						if store, ok := indexRef.(*ssa.Store); ok {
-							if a.isTainted(store.Val, fn, visited, depth+1) {
+							if a.isTaintedAt(store.Val, store.Block(), fn, visited, depth+1) {
 								return true
 							}
 						}
@@ -718,8 +1623,23 @@ func (a *Analyzer) isTainted(v ssa.Value, fn *ssa.Function, visited map[ssa.Valu
 		// Map/string lookup - check the map/string
 		return a.isTainted(val.X, fn, visited, depth+1)
 
+	case *ssa.Index:
+		// Element of an array value, such as the values of a range loop over
+		// an array - check the array
+		return a.isTainted(val.X, fn, visited, depth+1)
+
+	case *ssa.Next:
+		// Key and value of a range loop over a map or string - check the
+		// iterated map or string
+		return a.isTainted(val.Iter, fn, visited, depth+1)
+
+	case *ssa.Range:
+		// Iterator of a range loop - check the map or string being ranged over
+		return a.isTainted(val.X, fn, visited, depth+1)
+
 	case *ssa.MakeSlice:
-		// MakeSlice - check if it's being populated with tainted data
+		// MakeSlice - check if it's being populated with tainted data.
+		// The whole slice is tainted once any element is.
 		if refs := val.Referrers(); refs != nil {
 			for _, ref := range *refs {
 				if store, ok := ref.(*ssa.Store); ok {
@@ -727,6 +1647,14 @@ func (a *Analyzer) isTainted(v ssa.Value, fn *ssa.Function, visited map[ssa.Valu
 						return true
 					}
 				}
+				// Element assignments, e.g. parts[i] = v
+				if indexAddr, ok := ref.(*ssa.IndexAddr); ok {
+					for _, v := range storesTo(indexAddr) {
+						if a.isTainted(v, fn, visited, depth+1) {
+							return true
+						}
+					}
+				}
 				if call, ok := ref.(*ssa.Call); ok {
 					for _, arg := range call.Call.Args {
 						if arg == val {
@@ -741,8 +1669,33 @@ func (a *Analyzer) isTainted(v ssa.Value, fn *ssa.Function, visited map[ssa.Valu
 		}
 		return false
 
-	case *ssa.MakeMap, *ssa.MakeChan:
-		// New maps/channels are not tainted by default
+	case *ssa.MakeMap:
+		// A map is tainted if any value stored into it is tainted. Keys are not
+		// tracked individually, so every lookup on such a map is tainted.
+		if refs := val.Referrers(); refs != nil {
+			for _, ref := range *refs {
+				if update, ok := ref.(*ssa.MapUpdate); ok && update.Map == val {
+					if a.isTainted(update.Value, fn, visited, depth+1) {
+						return true
+					}
+				}
+			}
+		}
+		return false
+
+	case *ssa.MakeChan:
+		// A channel is tainted if any value sent on it is tainted, so every
+		// receive from it (UnOp with token.ARROW) inherits that taint.
+		return a.isChanTainted(val, fn, visited, depth+1)
+
+	case *ssa.Select:
+		// Select statement - received values are tainted if any receive case
+		// reads from a tainted channel
+		for _, state := range val.States {
+			if state.Dir == types.RecvOnly && a.isTainted(state.Chan, fn, visited, depth+1) {
+				return true
+			}
+		}
 		return false
 
 	case *ssa.Const:
@@ -757,6 +1710,13 @@ func (a *Analyzer) isTainted(v ssa.Value, fn *ssa.Function, visited map[ssa.Valu
 				return true
 			}
 		}
+		// A package-level variable is tainted if any store to it is tainted.
+		// Stores are not ordered, so taint is unioned across all of them.
+		for _, store := range a.globalStores[globalField{global: val, field: wholeGlobal}] {
+			if a.isTainted(store.Val, store.Parent(), visited, depth+1) {
+				return true
+			}
+		}
 		return false
 
 	case *ssa.FreeVar:
@@ -774,6 +1734,163 @@ func (a *Analyzer) isTainted(v ssa.Value, fn *ssa.Function, visited map[ssa.Valu
 	return false
 }
 
+// boundReceiver returns the receiver bound into v when v is a method value
+// such as f.Query, which SSA builds as a closure over a bound method wrapper.
+func boundReceiver(v ssa.Value) (ssa.Value, bool) {
+	closure, ok := v.(*ssa.MakeClosure)
+	if !ok || len(closure.Bindings) != 1 {
+		return nil, false
+	}
+	wrapper, ok := closure.Fn.(*ssa.Function)
+	if !ok {
+		return nil, false
+	}
+	// Unlike function literals, bound method wrappers carry the method object
+	method, ok := wrapper.Object().(*types.Func)
+	if !ok || method.Signature().Recv() == nil {
+		return nil, false
+	}
+	return closure.Bindings[0], true
+}
+
+// isFormatCallTainted checks if any argument of a call to one of formatFuncs
+// is tainted. The elements of the variadic slice are checked one by one, so
+// that each is checked where the call is made, as it would be if passed
+// directly.
+func (a *Analyzer) isFormatCallTainted(call *ssa.Call, fn *ssa.Function, visited map[ssa.Value]bool, depth int) bool {
+	args := call.Call.Args
+	if len(args) == 0 {
+		return false
+	}
+	last := len(args) - 1
+	for _, arg := range args[:last] {
+		if a.isTaintedAt(arg, call.Block(), fn, visited, depth) {
+			return true
+		}
+	}
+	elems, ok := variadicElems(args[last])
+	if !ok {
+		// A slice passed on with args...
+		return a.isTainted(args[last], fn, visited, depth)
+	}
+	for _, elem := range elems {
+		if a.isTaintedAt(elem, call.Block(), fn, visited, depth) {
+			return true
+		}
+	}
+	return false
+}
+
+// isMarshalTainted checks if the value encoded by a call to one of
+// marshalFuncs is tainted. A struct is checked field by field, since a
+// locally built struct is not otherwise tainted by the values stored into its
+// fields.
+func (a *Analyzer) isMarshalTainted(call *ssa.Call, fn *ssa.Function, visited map[ssa.Value]bool, depth int) bool {
+	if len(call.Call.Args) == 0 {
+		return false
+	}
+	v := call.Call.Args[0]
+	if mi, ok := v.(*ssa.MakeInterface); ok {
+		v = mi.X
+	}
+	// A struct passed by value is loaded from its allocation
+	if load, ok := v.(*ssa.UnOp); ok && load.Op == token.MUL {
+		v = load.X
+	}
+	if ptr, ok := v.Type().Underlying().(*types.Pointer); ok {
+		if st, ok := ptr.Elem().Underlying().(*types.Struct); ok {
+			for i := 0; i < st.NumFields(); i++ {
+				if a.isFieldTaintedOnValue(v, i, fn, visited, depth) {
+					return true
+				}
+			}
+		}
+	}
+	return a.isTainted(call.Call.Args[0], fn, visited, depth)
+}
+
+// variadicElems returns the values stored into the implicit slice built for
+// the variadic arguments of a call. It reports false when v is not such a
+// slice, as when an existing slice is passed on with args...
+func variadicElems(v ssa.Value) ([]ssa.Value, bool) {
+	if _, ok := v.(*ssa.Const); ok {
+		// No variadic arguments
+		return nil, true
+	}
+	slice, ok := v.(*ssa.Slice)
+	if !ok {
+		return nil, false
+	}
+	alloc, ok := slice.X.(*ssa.Alloc)
+	if !ok || alloc.Referrers() == nil {
+		return nil, false
+	}
+	var elems []ssa.Value
+	for _, ref := range *alloc.Referrers() {
+		if indexAddr, ok := ref.(*ssa.IndexAddr); ok && indexAddr.X == alloc {
+			elems = append(elems, storesTo(indexAddr)...)
+		}
+	}
+	return elems, true
+}
+
+// isCopyTainted checks if the copy builtin copies tainted data into v, or
+// into a slice of v such as the buffer of make with a constant length or the
+// tail buf[n:] of a buffer being filled.
+func (a *Analyzer) isCopyTainted(v ssa.Value, fn *ssa.Function, visited map[ssa.Value]bool, depth int) bool {
+	refs := v.Referrers()
+	if refs == nil {
+		return false
+	}
+	for _, ref := range *refs {
+		switch r := ref.(type) {
+		case *ssa.Call:
+			builtin, ok := r.Call.Value.(*ssa.Builtin)
+			if !ok || builtin.Name() != "copy" || len(r.Call.Args) != 2 || r.Call.Args[0] != v {
+				continue
+			}
+			if a.isTainted(r.Call.Args[1], fn, visited, depth+1) {
+				return true
+			}
+		case *ssa.Slice:
+			if r.X == v && a.isCopyTainted(r, fn, visited, depth+1) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isDecodeTainted checks if v, directly or converted to an interface, is
+// filled by one of decodeFuncs from tainted data, as in
+// json.Unmarshal(body, &v) or json.NewDecoder(r.Body).Decode(&v).
+func (a *Analyzer) isDecodeTainted(v ssa.Value, fn *ssa.Function, visited map[ssa.Value]bool, depth int) bool {
+	refs := v.Referrers()
+	if refs == nil {
+		return false
+	}
+	for _, ref := range *refs {
+		switch r := ref.(type) {
+		case *ssa.MakeInterface:
+			if a.isDecodeTainted(r, fn, visited, depth) {
+				return true
+			}
+		case *ssa.Call:
+			callee := r.Call.StaticCallee()
+			args := r.Call.Args
+			if callee == nil || !decodeFuncs[callee.String()] || len(args) == 0 || args[len(args)-1] != v {
+				continue
+			}
+			for _, arg := range args[:len(args)-1] {
+				if a.isTainted(arg, fn, visited, depth) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
 // isSourceType checks if a type matches any configured source type.
 // This is used specifically for parameter checking, NOT for general value checking.
 func (a *Analyzer) isSourceType(t types.Type) bool {
@@ -841,20 +1958,101 @@ func mayHaveExternalCallers(fn *ssa.Function) bool {
 // isSourceFuncCall checks if a call invokes a known source function
 // (a function explicitly configured as producing tainted data, e.g., os.Getenv).
 func (a *Analyzer) isSourceFuncCall(call *ssa.Call) bool {
+	if call.Call.IsInvoke() {
+		// Interface method source, e.g. (web.Context).Param
+		named, ok := call.Call.Value.Type().(*types.Named)
+		if !ok || named.Obj().Pkg() == nil {
+			return false
+		}
+		key := formatSourceKey(Source{
+			Package:  named.Obj().Pkg().Path(),
+			Receiver: named.Obj().Name(),
+			Name:     call.Call.Method.Name(),
+		})
+		src, ok := a.sources[key]
+		return ok && src.IsFunc
+	}
+
 	callee := call.Call.StaticCallee()
-	if callee == nil {
+	if callee == nil || callee.Pkg == nil || callee.Pkg.Pkg == nil {
 		return false
 	}
 
-	if callee.Pkg != nil && callee.Pkg.Pkg != nil {
-		pkg := callee.Pkg.Pkg.Path()
-		funcKey := pkg + "." + callee.Name()
-		if src, ok := a.sources[funcKey]; ok && src.IsFunc {
+	key := formatSourceKey(Source{
+		Package: callee.Pkg.Pkg.Path(),
+		Name:    callee.Name(),
+	})
+	if recv := callee.Signature.Recv(); recv != nil {
+		recvName, isPointer := receiverTypeName(recv.Type())
+		key = formatSourceKey(Source{
+			Package:  callee.Pkg.Pkg.Path(),
+			Receiver: recvName,
+			Name:     callee.Name(),
+			Pointer:  isPointer,
+		})
+	}
+	src, ok := a.sources[key]
+	return (ok && src.IsFunc) || a.isProtoGetter(callee)
+}
+
+// isProtoGetter reports whether fn is a getter of a string field of a
+// protobuf message, such as (*pb.GetUserRequest).GetName, when ProtoGetters
+// is set. Request messages of gRPC services hold the caller's data, so all
+// such getters are sources.
+func (a *Analyzer) isProtoGetter(fn *ssa.Function) bool {
+	if !a.config.ProtoGetters {
+		return false
+	}
+	sig := fn.Signature
+	if sig.Recv() == nil || !strings.HasPrefix(fn.Name(), "Get") || len(fn.Name()) == len("Get") ||
+		sig.Params().Len() != 0 || sig.Results().Len() != 1 {
+		return false
+	}
+	if basic, ok := sig.Results().At(0).Type().Underlying().(*types.Basic); !ok || basic.Kind() != types.String {
+		return false
+	}
+	ptr, ok := sig.Recv().Type().(*types.Pointer)
+	if !ok {
+		return false
+	}
+	named, ok := ptr.Elem().(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return false
+	}
+	return a.isProtoMessage(ptr, named.Obj().Pkg().Path())
+}
+
+// isProtoMessage reports whether t, declared in the package at path, is a
+// protobuf message: it has the ProtoMessage marker method of generated
+// messages, or its package is named like generated protobuf packages, e.g.
+// userpb, or is one of the configured ProtoPackages.
+func (a *Analyzer) isProtoMessage(t types.Type, path string) bool {
+	if obj, _, _ := types.LookupFieldOrMethod(t, true, nil, "ProtoMessage"); obj != nil {
+		if _, ok := obj.(*types.Func); ok {
 			return true
 		}
 	}
+	name := path[strings.LastIndex(path, "/")+1:]
+	if strings.HasSuffix(name, "pb") || strings.HasSuffix(name, "proto") {
+		return true
+	}
+	return slices.ContainsFunc(a.config.ProtoPackages, func(prefix string) bool {
+		return strings.HasPrefix(path, prefix)
+	})
+}
 
-	return false
+// receiverTypeName returns the name of a method receiver's named type and
+// whether the receiver is a pointer.
+func receiverTypeName(t types.Type) (string, bool) {
+	isPointer := false
+	if ptr, ok := t.(*types.Pointer); ok {
+		isPointer = true
+		t = ptr.Elem()
+	}
+	if named, ok := t.(*types.Named); ok {
+		return named.Obj().Name(), isPointer
+	}
+	return "", isPointer
 }
 
 // isParameterTainted checks if a function parameter receives tainted data.
@@ -878,9 +2076,9 @@ func (a *Analyzer) isParameterTainted(param *ssa.Parameter, fn *ssa.Function, vi
 	}
 
 	// Check memoization cache (only true results are cached).
-	if paramIdx >= 0 && a.paramTaintCache != nil {
+	if paramIdx >= 0 {
 		key := paramKey{fn: fn, paramIdx: paramIdx}
-		if a.paramTaintCache[key] {
+		if a.paramTaintCache.has(key) {
 			return true
 		}
 	}
@@ -890,8 +2088,8 @@ func (a *Analyzer) isParameterTainted(param *ssa.Parameter, fn *ssa.Function, vi
 		// No call graph: fall back to type-based auto-taint for source-typed params
 		// (conservative — may produce false positives, but we have no callee info).
 		if a.isSourceType(param.Type()) {
-			if paramIdx >= 0 && a.paramTaintCache != nil {
-				a.paramTaintCache[paramKey{fn: fn, paramIdx: paramIdx}] = true
+			if paramIdx >= 0 {
+				a.paramTaintCache.add(paramKey{fn: fn, paramIdx: paramIdx})
 			}
 			return true
 		}
@@ -916,8 +2114,8 @@ func (a *Analyzer) isParameterTainted(param *ssa.Parameter, fn *ssa.Function, vi
 	if a.isSourceType(param.Type()) {
 		isEntryPoint := (node == nil || len(node.In) == 0)
 		if isEntryPoint || mayHaveExternalCallers(fn) {
-			if paramIdx >= 0 && a.paramTaintCache != nil {
-				a.paramTaintCache[paramKey{fn: fn, paramIdx: paramIdx}] = true
+			if paramIdx >= 0 {
+				a.paramTaintCache.add(paramKey{fn: fn, paramIdx: paramIdx})
 			}
 			return true
 		}
@@ -946,6 +2144,15 @@ func (a *Analyzer) isParameterTainted(param *ssa.Parameter, fn *ssa.Function, vi
 		adjustedIdx = paramIdx
 	}
 
+	// Past the configured call depth, assume that the callers pass tainted
+	// data rather than missing a flow.
+	if len(node.In) > 0 && a.callDepthExceeded() {
+		a.widen(param, fmt.Sprintf("call depth limit of %d reached; callers of %s assumed to pass tainted data", a.config.MaxCallDepth, fn.Name()))
+		return true
+	}
+	a.callDepth++
+	defer func() { a.callDepth-- }()
+
 	// Check each caller, capping at maxCallerEdges to avoid combinatorial
 	// explosion from CHA over-approximation of interface method calls.
 	edgesChecked := 0
@@ -960,13 +2167,20 @@ func (a *Analyzer) isParameterTainted(param *ssa.Parameter, fn *ssa.Function, vi
 		}
 
 		callArgs := site.Common().Args
+		if site.Common().IsInvoke() && fn.Signature.Recv() != nil {
+			// Dispatch through an interface: the receiver is Call.Value and
+			// Args only hold the method parameters. CHA resolves the call to
+			// every implementation, so each of them is checked.
+			callArgs = append([]ssa.Value{site.Common().Value}, callArgs...)
+		}
 
 		if adjustedIdx < len(callArgs) {
 			edgesChecked++
 			if a.isTainted(callArgs[adjustedIdx], inEdge.Caller.Func, visited, depth+1) {
-				if a.paramTaintCache != nil {
-					a.paramTaintCache[paramKey{fn: fn, paramIdx: paramIdx}] = true
+				if site.Common().IsInvoke() {
+					a.widen(param, fmt.Sprintf("interface call resolved to %s, which may not be the dynamic type", fn.String()))
 				}
+				a.paramTaintCache.add(paramKey{fn: fn, paramIdx: paramIdx})
 				return true
 			}
 		}
@@ -1011,6 +2225,129 @@ func (a *Analyzer) isFreeVarTainted(fv *ssa.FreeVar, fn *ssa.Function, visited m
 	return false
 }
 
+// isBufferWriteTainted checks whether tainted data is written into the buffer
+// at addr, either through one of its write methods or through a function such
+// as fmt.Fprintf that receives it as an io.Writer.
+func (a *Analyzer) isBufferWriteTainted(addr ssa.Value, fn *ssa.Function, visited map[ssa.Value]bool, depth int) bool {
+	if depth > maxTaintDepth {
+		return false
+	}
+	refs := addr.Referrers()
+	if refs == nil {
+		return false
+	}
+
+	for _, ref := range *refs {
+		switch r := ref.(type) {
+		case *ssa.Call:
+			callee := r.Call.StaticCallee()
+			if callee == nil || len(r.Call.Args) == 0 || r.Call.Args[0] != addr || !bufferWriteMethods[callee.String()] {
+				continue
+			}
+			for _, arg := range r.Call.Args[1:] {
+				if a.isTainted(arg, fn, visited, depth+1) {
+					return true
+				}
+			}
+		case *ssa.MakeInterface:
+			// Buffer passed as an io.Writer
+			writerRefs := r.Referrers()
+			if writerRefs == nil {
+				continue
+			}
+			for _, wref := range *writerRefs {
+				call, ok := wref.(*ssa.Call)
+				if !ok || len(call.Call.Args) == 0 || call.Call.Args[0] != r {
+					continue
+				}
+				callee := call.Call.StaticCallee()
+				if callee == nil || !writerFuncs[callee.String()] {
+					continue
+				}
+				for _, arg := range call.Call.Args[1:] {
+					if a.isTainted(arg, fn, visited, depth+1) {
+						return true
+					}
+				}
+			}
+		}
+	}
+	return false
+}
+
+// isChanTainted checks whether any value sent on the channel ch is tainted.
+// Sends made from closures capturing the channel and from functions the channel
+// is passed to (including goroutines) are followed as well.
+func (a *Analyzer) isChanTainted(ch ssa.Value, fn *ssa.Function, visited map[ssa.Value]bool, depth int) bool {
+	if depth > maxTaintDepth {
+		return false
+	}
+	for _, send := range chanSends(ch, make(map[ssa.Value]bool)) {
+		if a.isTainted(send.X, send.Parent(), visited, depth+1) {
+			return true
+		}
+	}
+	return false
+}
+
+// chanSends collects the send instructions on the channel ch. ch may also be
+// the address of a variable holding the channel, since closures capture
+// variables by reference.
+func chanSends(ch ssa.Value, seen map[ssa.Value]bool) []*ssa.Send {
+	if seen[ch] {
+		return nil
+	}
+	seen[ch] = true
+	refs := ch.Referrers()
+	if refs == nil {
+		return nil
+	}
+
+	var sends []*ssa.Send
+	for _, ref := range *refs {
+		switch r := ref.(type) {
+		case *ssa.Send:
+			if r.Chan == ch {
+				sends = append(sends, r)
+			}
+		case *ssa.Store:
+			// Channel kept in a variable: follow the variable
+			if alloc, ok := r.Addr.(*ssa.Alloc); ok && r.Val == ch {
+				sends = append(sends, chanSends(alloc, seen)...)
+			}
+		case *ssa.UnOp:
+			// Load of the channel from a variable
+			if r.Op == token.MUL && r.X == ch {
+				sends = append(sends, chanSends(r, seen)...)
+			}
+		case *ssa.MakeClosure:
+			// Channel captured by a closure: follow the free variable
+			closure, ok := r.Fn.(*ssa.Function)
+			if !ok {
+				continue
+			}
+			for i, binding := range r.Bindings {
+				if binding == ch && i < len(closure.FreeVars) {
+					sends = append(sends, chanSends(closure.FreeVars[i], seen)...)
+				}
+			}
+		case ssa.CallInstruction:
+			// Channel passed to a function or goroutine: follow the parameter
+			common := r.Common()
+			callee := common.StaticCallee()
+			if callee == nil || callee.Blocks == nil {
+				continue
+			}
+			for i, arg := range common.Args {
+				if arg == ch && i < len(callee.Params) {
+					sends = append(sends, chanSends(callee.Params[i], seen)...)
+				}
+			}
+		}
+	}
+	return sends
+}
+
 // isFieldAccessTainted checks whether a specific field of a struct carries tainted data.
 //
 // This is the core of field-sensitive taint tracking. Rather than treating
@@ -1058,6 +2395,11 @@ func (a *Analyzer) isFieldAccessTainted(fa *ssa.FieldAddr, fn *ssa.Function, vis
 
 	// CASE 4: The struct is a local Alloc. Check stores to this specific field.
 	if alloc, ok := fa.X.(*ssa.Alloc); ok {
+		// A store earlier in the same block overwrites the field, as in a
+		// swap: p.x, p.y = p.y, p.x
+		if store := lastFieldStore(fa); store != nil && !a.isDecodeTainted(alloc, fn, visited, depth+1) {
+			return a.isTainted(store.Val, fn, visited, depth+1)
+		}
 		return a.isFieldOfAllocTainted(alloc, fa.Field, fn, visited, depth)
 	}
 
@@ -1076,15 +2418,134 @@ func (a *Analyzer) isFieldAccessTainted(fa *ssa.FieldAddr, fn *ssa.Function, vis
 		return false
 	}
 
-	// CASE 7: Nested field access — e.g., job.Rinse.Something
+	// CASE 7: Field of a package-level struct variable. Check stores to the
+	// same field anywhere in the package, then assignments of the whole struct.
+	if global, ok := fa.X.(*ssa.Global); ok {
+		for _, store := range a.globalStores[globalField{global: global, field: fa.Field}] {
+			if a.isTainted(store.Val, store.Parent(), visited, depth+1) {
+				return true
+			}
+		}
+		return a.isTainted(global, fn, visited, depth)
+	}
+
+	// CASE 8: Nested field access on a struct embedded by value — e.g., job.Rinse.Something
 	if innerFA, ok := fa.X.(*ssa.FieldAddr); ok {
-		return a.isFieldAccessTainted(innerFA, fn, visited, depth)
+		addrs, resolved := fieldAddrsOf(innerFA, fa.Field, 0)
+		if !resolved {
+			return a.isFieldAccessTainted(innerFA, fn, visited, depth)
+		}
+		if a.isStoreToFieldTainted(addrs, fn, visited, depth) {
+			return true
+		}
+		// The embedded struct may also have been assigned as a whole
+		whole, _ := storedFieldValues(innerFA.X, innerFA.Field, 0)
+		for _, v := range whole {
+			if a.isTainted(v, fn, visited, depth+1) {
+				return true
+			}
+		}
+		return false
+	}
+
+	// CASE 9: Pointer held in a field of a struct value — e.g., req.Name
+	// where req embeds a *Filter, read as (*req).Filter.Name
+	if field, ok := fa.X.(*ssa.Field); ok {
+		if load, ok := field.X.(*ssa.UnOp); ok && load.Op == token.MUL {
+			if outer, ok := fieldAddrsOf(load.X, field.Field, 0); ok {
+				return a.isFieldOfPointerFieldTainted(outer, fa.Field, fn, visited, depth)
+			}
+		}
+	}
+
+	// CASE 10: A source held in a field of a parameter, e.g. c.req in a
+	// handler receiving the request wrapped in a context struct. The field
+	// is checked like a parameter of the source type.
+	if param, ok := fa.X.(*ssa.Parameter); ok && a.isSourceType(structFieldType(param.Type(), fa.Field)) {
+		return a.isSourceFieldOfParamTainted(param, fa.Field, fn, visited, depth)
 	}
 
 	// Default: fall back to checking if the parent struct value is tainted.
 	return a.isTainted(fa.X, fn, visited, depth)
 }
 
+// structFieldType returns the type of field fieldIdx of the struct pointed to
+// by t, or nil if t is not a pointer to a struct with such a field.
+func structFieldType(t types.Type, fieldIdx int) types.Type {
+	ptr, ok := t.Underlying().(*types.Pointer)
+	if !ok {
+		return nil
+	}
+	st, ok := ptr.Elem().Underlying().(*types.Struct)
+	if !ok || fieldIdx >= st.NumFields() {
+		return nil
+	}
+	return st.Field(fieldIdx).Type()
+}
+
+// isSourceFieldOfParamTainted checks whether the field fieldIdx of a source
+// type, such as a *http.Request, of the struct pointed to by param is tainted.
+// Like a parameter of the source type, the field is tainted in an entry point;
+// otherwise it is tainted if a caller stores a tainted value into it.
+func (a *Analyzer) isSourceFieldOfParamTainted(param *ssa.Parameter, fieldIdx int, fn *ssa.Function, visited map[ssa.Value]bool, depth int) bool {
+	if a.callGraph == nil {
+		return true
+	}
+	node := a.callGraph.Nodes[fn]
+	if node == nil || len(node.In) == 0 || mayHaveExternalCallers(fn) {
+		return true
+	}
+	paramIdx := slices.Index(fn.Params, param)
+	if paramIdx < 0 {
+		return false
+	}
+	edgesChecked := 0
+	for _, inEdge := range node.In {
+		if edgesChecked >= maxCallerEdges {
+			break
+		}
+		site := inEdge.Site
+		if site == nil {
+			continue
+		}
+		callArgs := site.Common().Args
+		if site.Common().IsInvoke() && fn.Signature.Recv() != nil {
+			callArgs = append([]ssa.Value{site.Common().Value}, callArgs...)
+		}
+		if paramIdx < len(callArgs) {
+			edgesChecked++
+			if a.isFieldTaintedOnValue(callArgs[paramIdx], fieldIdx, inEdge.Caller.Func, visited, depth+1) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// lastFieldStore returns the store to the same field of the same struct
+// that precedes the field address read in its block, or nil if there is
+// none. Calls in between may write the field through another pointer, so
+// the search stops at them.
+func lastFieldStore(read *ssa.FieldAddr) *ssa.Store {
+	instrs := read.Block().Instrs
+	i := slices.Index(instrs, ssa.Instruction(read))
+	for i--; i >= 0; i-- {
+		switch instr := instrs[i].(type) {
+		case ssa.CallInstruction:
+			return nil
+		case *ssa.Store:
+			if instr.Addr == read.X {
+				// The whole struct is assigned
+				return nil
+			}
+			if fa, ok := instr.Addr.(*ssa.FieldAddr); ok && fa.X == read.X && fa.Field == read.Field {
+				return instr
+			}
+		}
+	}
+	return nil
+}
+
 // isFieldTaintedOnValue checks if a specific field of a value is tainted.
 func (a *Analyzer) isFieldTaintedOnValue(v ssa.Value, fieldIdx int, fn *ssa.Function, visited map[ssa.Value]bool, depth int) bool {
 	if v == nil || depth > maxTaintDepth {
@@ -1106,6 +2567,15 @@ func (a *Analyzer) isFieldTaintedOnValue(v ssa.Value, fieldIdx int, fn *ssa.Func
 		return a.isTainted(v, fn, visited, depth)
 	case *ssa.Alloc:
 		return a.isFieldOfAllocTainted(val, fieldIdx, fn, visited, depth)
+	case *ssa.Parameter:
+		// A wrapped source passed on, e.g. through a method expression thunk
+		if a.isSourceType(structFieldType(val.Type(), fieldIdx)) {
+			return a.isSourceFieldOfParamTainted(val, fieldIdx, fn, visited, depth)
+		}
+		return a.isTainted(v, fn, visited, depth)
+	case *ssa.FieldAddr:
+		// Pointer loaded from a struct field, e.g. req.Query in req.Query.SQL
+		return a.isNestedFieldTainted(val, fieldIdx, fn, visited, depth)
 	case *ssa.Phi:
 		if visited[v] {
 			return false
@@ -1128,12 +2598,22 @@ func (a *Analyzer) isFieldOfAllocTainted(alloc *ssa.Alloc, fieldIdx int, fn *ssa
 	if alloc.Referrers() == nil {
 		return false
 	}
+	// A struct decoded from tainted data, e.g. json.NewDecoder(r.Body).Decode(&in),
+	// is tainted as a whole: any of its fields may hold the attacker's data
+	if a.isDecodeTainted(alloc, fn, visited, depth+1) {
+		return true
+	}
 	for _, ref := range *alloc.Referrers() {
 		fa, ok := ref.(*ssa.FieldAddr)
 		if !ok || fa.Field != fieldIdx {
 			continue
 		}
 
+		// Buffer fields written in place, e.g. q.buf.WriteString(v)
+		if a.isBufferWriteTainted(fa, fn, visited, depth+1) {
+			return true
+		}
+
 		if fa.Referrers() == nil {
 			continue
 		}
@@ -1147,9 +2627,285 @@ func (a *Analyzer) isFieldOfAllocTainted(alloc *ssa.Alloc, fieldIdx int, fn *ssa
 			}
 		}
 	}
+	// Fields set by a method or function the struct is passed to
+	return a.isSetterTainted(alloc, fieldIdx, fn, visited, depth+1)
+}
+
+// anyField stands for every field of a struct in isSetterTainted.
+const anyField = -1
+
+// isSetterTainted checks whether a call receiving the struct pointer ptr as
+// an argument stores tainted data from its arguments into field fieldIdx of
+// the struct, or into any field when fieldIdx is anyField. This is what a
+// setter does with its receiver: qb.SetTable(v) followed by a qb.Build()
+// reading the field.
+func (a *Analyzer) isSetterTainted(ptr ssa.Value, fieldIdx int, fn *ssa.Function, visited map[ssa.Value]bool, depth int) bool {
+	if depth > maxTaintDepth || ptr.Referrers() == nil {
+		return false
+	}
+	for _, ref := range *ptr.Referrers() {
+		call, ok := ref.(*ssa.Call)
+		if !ok {
+			continue
+		}
+		callee := call.Call.StaticCallee()
+		if callee == nil || len(callee.Blocks) == 0 {
+			continue
+		}
+		for i, arg := range call.Call.Args {
+			if arg != ptr || i >= len(callee.Params) {
+				continue
+			}
+			param := callee.Params[i]
+			for _, pref := range *param.Referrers() {
+				fa, ok := pref.(*ssa.FieldAddr)
+				if !ok || fa.X != param || (fieldIdx != anyField && fa.Field != fieldIdx) {
+					continue
+				}
+				for _, v := range storesTo(fa) {
+					if a.isCalleValueTainted(v, callee, call, fn, visited, depth+1) {
+						return true
+					}
+				}
+			}
+		}
+	}
+	return false
+}
+
+// isNestedFieldTainted checks whether a field of a struct reached through a
+// pointer-valued field is tainted, e.g. SQL in req.Query.SQL where addr is
+// &req.Query. The pointers stored into addr are resolved level by level, so
+// paths of any length are followed up to maxFieldPathDepth.
+func (a *Analyzer) isNestedFieldTainted(addr *ssa.FieldAddr, fieldIdx int, fn *ssa.Function, visited map[ssa.Value]bool, depth int) bool {
+	if depth > maxTaintDepth {
+		return false
+	}
+
+	// The outer struct was returned by a call: resolve the path inside the callee
+	if call := structConstructorCall(addr.X); call != nil {
+		return a.isNestedFieldTaintedViaCall(call, addr.Field, fieldIdx, fn, visited, depth+1)
+	}
+
+	outer, ok := fieldAddrsOf(addr.X, addr.Field, 0)
+	if !ok {
+		// The path could not be resolved; fall back to the whole outer field
+		return a.isFieldAccessTainted(addr, fn, visited, depth+1)
+	}
+	return a.isFieldOfPointerFieldTainted(outer, fieldIdx, fn, visited, depth)
+}
+
+// isFieldOfPointerFieldTainted checks whether field fieldIdx of the structs
+// pointed to by the pointer-valued fields at addrs is tainted. Besides the
+// structs whose pointers were stored there, this covers writes made through
+// the pointers read back from the fields, e.g. req.Name = v where req embeds
+// a *Filter.
+func (a *Analyzer) isFieldOfPointerFieldTainted(addrs []*ssa.FieldAddr, fieldIdx int, fn *ssa.Function, visited map[ssa.Value]bool, depth int) bool {
+	for _, fa := range addrs {
+		for _, ptr := range storesTo(fa) {
+			if a.isFieldTaintedOnValue(ptr, fieldIdx, fn, visited, depth+1) {
+				return true
+			}
+		}
+	}
+	var written []*ssa.FieldAddr
+	for _, ptr := range fieldPointers(addrs) {
+		for _, ref := range *ptr.Referrers() {
+			if fa, ok := ref.(*ssa.FieldAddr); ok && fa.X == ptr && fa.Field == fieldIdx {
+				written = append(written, fa)
+			}
+		}
+	}
+	return a.isStoreToFieldTainted(written, fn, visited, depth)
+}
+
+// fieldPointers returns the values read from the pointer-valued fields at
+// addrs, either loaded through the field address or taken from a copy of the
+// whole struct, as SSA does for promoted fields of an embedded pointer.
+func fieldPointers(addrs []*ssa.FieldAddr) []ssa.Value {
+	var ptrs []ssa.Value
+	structs := make(map[ssa.Value]bool)
+	for _, addr := range addrs {
+		for _, ref := range *addr.Referrers() {
+			if load, ok := ref.(*ssa.UnOp); ok && load.Op == token.MUL && load.Referrers() != nil {
+				ptrs = append(ptrs, load)
+			}
+		}
+		if structs[addr.X] {
+			continue
+		}
+		structs[addr.X] = true
+		for _, ref := range *addr.X.Referrers() {
+			load, ok := ref.(*ssa.UnOp)
+			if !ok || load.Op != token.MUL || load.Referrers() == nil {
+				continue
+			}
+			for _, loadRef := range *load.Referrers() {
+				if field, ok := loadRef.(*ssa.Field); ok && field.Field == addr.Field && field.Referrers() != nil {
+					ptrs = append(ptrs, field)
+				}
+			}
+		}
+	}
+	return ptrs
+}
+
+// isNestedFieldTaintedViaCall checks whether the nested field fieldIdx of the
+// struct stored in field outerField of the struct returned by call is tainted.
+func (a *Analyzer) isNestedFieldTaintedViaCall(call *ssa.Call, outerField, fieldIdx int, callerFn *ssa.Function, visited map[ssa.Value]bool, depth int) bool {
+	callee := call.Call.StaticCallee()
+	for _, block := range callee.Blocks {
+		for _, instr := range block.Instrs {
+			ret, ok := instr.(*ssa.Return)
+			if !ok {
+				continue
+			}
+			for _, retVal := range ret.Results {
+				alloc := traceToAlloc(retVal)
+				if alloc == nil {
+					continue
+				}
+				ptrs, _ := storedFieldValues(alloc, outerField, 0)
+				for _, ptr := range ptrs {
+					addrs, ok := fieldAddrsOf(ptr, fieldIdx, 0)
+					if !ok {
+						// Unknown inner struct, e.g. a parameter: check it as a whole
+						if a.isCalleValueTainted(ptr, callee, call, callerFn, visited, depth+1) {
+							return true
+						}
+						continue
+					}
+					for _, fa := range addrs {
+						for _, v := range storesTo(fa) {
+							if a.isCalleValueTainted(v, callee, call, callerFn, visited, depth+1) {
+								return true
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+	return false
+}
+
+// isStoreToFieldTainted reports whether any value stored through the given
+// field addresses is tainted.
+func (a *Analyzer) isStoreToFieldTainted(addrs []*ssa.FieldAddr, fn *ssa.Function, visited map[ssa.Value]bool, depth int) bool {
+	for _, fa := range addrs {
+		for _, v := range storesTo(fa) {
+			if a.isTainted(v, fn, visited, depth+1) {
+				return true
+			}
+		}
+	}
 	return false
 }
 
+// structConstructorCall returns the call producing v when v is the result of a
+// call to a function with an available body.
+func structConstructorCall(v ssa.Value) *ssa.Call {
+	if extract, ok := v.(*ssa.Extract); ok {
+		v = extract.Tuple
+	}
+	call, ok := v.(*ssa.Call)
+	if !ok {
+		return nil
+	}
+	if callee := call.Call.StaticCallee(); callee == nil || callee.Blocks == nil {
+		return nil
+	}
+	return call
+}
+
+// fieldAddrsOf returns every address of the given field of the struct pointed
+// to by base, following nested field paths back to the allocation. It returns
+// false when the struct does not originate from a local allocation.
+func fieldAddrsOf(base ssa.Value, field int, depth int) ([]*ssa.FieldAddr, bool) {
+	if depth > maxFieldPathDepth {
+		return nil, false
+	}
+
+	var structs []ssa.Value
+	switch b := base.(type) {
+	case *ssa.Alloc:
+		structs = append(structs, b)
+	case *ssa.FieldAddr:
+		// Struct embedded by value: its fields are addressed off the outer field
+		outer, ok := fieldAddrsOf(b.X, b.Field, depth+1)
+		if !ok {
+			return nil, false
+		}
+		for _, fa := range outer {
+			structs = append(structs, fa)
+		}
+	case *ssa.UnOp:
+		// Pointer loaded from a struct field: follow every pointer stored there
+		addr, ok := b.X.(*ssa.FieldAddr)
+		if !ok || b.Op != token.MUL {
+			return nil, false
+		}
+		ptrs, ok := storedFieldValues(addr.X, addr.Field, depth+1)
+		if !ok {
+			return nil, false
+		}
+		var addrs []*ssa.FieldAddr
+		for _, ptr := range ptrs {
+			sub, ok := fieldAddrsOf(ptr, field, depth+1)
+			if !ok {
+				return nil, false
+			}
+			addrs = append(addrs, sub...)
+		}
+		return addrs, true
+	default:
+		return nil, false
+	}
+
+	var addrs []*ssa.FieldAddr
+	for _, s := range structs {
+		refs := s.Referrers()
+		if refs == nil {
+			continue
+		}
+		for _, ref := range *refs {
+			if fa, ok := ref.(*ssa.FieldAddr); ok && fa.X == s && fa.Field == field {
+				addrs = append(addrs, fa)
+			}
+		}
+	}
+	return addrs, true
+}
+
+// storedFieldValues returns the values stored into the given field of the
+// struct pointed to by base.
+func storedFieldValues(base ssa.Value, field int, depth int) ([]ssa.Value, bool) {
+	addrs, ok := fieldAddrsOf(base, field, depth)
+	if !ok {
+		return nil, false
+	}
+	var values []ssa.Value
+	for _, fa := range addrs {
+		values = append(values, storesTo(fa)...)
+	}
+	return values, true
+}
+
+// storesTo returns the values stored directly through addr.
+func storesTo(addr ssa.Value) []ssa.Value {
+	refs := addr.Referrers()
+	if refs == nil {
+		return nil
+	}
+	var values []ssa.Value
+	for _, ref := range *refs {
+		if store, ok := ref.(*ssa.Store); ok && store.Addr == addr {
+			values = append(values, store.Val)
+		}
+	}
+	return values
+}
+
 // isFieldAccessOnPointerTainted handles field access through a pointer dereference.
 func (a *Analyzer) isFieldAccessOnPointerTainted(unop *ssa.UnOp, fieldIdx int, fn *ssa.Function, visited map[ssa.Value]bool, depth int) bool {
 	// Trace through the pointer to find the underlying value
@@ -1355,16 +3111,40 @@ func (a *Analyzer) doTaintedArgsFlowToReturn(call *ssa.Call, callee *ssa.Functio
 		return false
 	}
 
-	// Build a set of callee parameters that correspond to tainted caller args
+	// Past the configured call depth, treat the helper like an external
+	// function whose return carries the taint of its arguments.
+	if a.callDepthExceeded() {
+		a.widen(call, fmt.Sprintf("call depth limit of %d reached; %s assumed to return its tainted arguments", a.config.MaxCallDepth, callee.Name()))
+		return true
+	}
+	a.callDepth++
+	defer func() { a.callDepth-- }()
+
+	// Whether the tainted parameters reach the return only depends on the
+	// callee, so the answer is computed once and shared by all call sites.
+	key := summaryKey{fn: callee, params: fmt.Sprint(taintedArgIndices)}
+	if flows, ok := a.summaries.lookup(key); ok {
+		return flows
+	}
+	flows := a.paramsFlowToReturn(callee, taintedArgIndices)
+	// A search cut short by the deadline may have missed flows.
+	if !a.aborted {
+		a.summaries.store(key, flows)
+	}
+	return flows
+}
+
+// paramsFlowToReturn checks if any of the parameters of fn at the given
+// indexes flows to a Return instruction.
+func (a *Analyzer) paramsFlowToReturn(fn *ssa.Function, paramIndices []int) bool {
 	taintedParams := make(map[*ssa.Parameter]bool)
-	for _, idx := range taintedArgIndices {
-		if idx < len(callee.Params) {
-			taintedParams[callee.Params[idx]] = true
+	for _, idx := range paramIndices {
+		if idx < len(fn.Params) {
+			taintedParams[fn.Params[idx]] = true
 		}
 	}
 
-	// Check if any tainted parameter flows to a Return instruction
-	for _, block := range callee.Blocks {
+	for _, block := range fn.Blocks {
 		for _, instr := range block.Instrs {
 			ret, ok := instr.(*ssa.Return)
 			if !ok {
@@ -1381,6 +3161,12 @@ func (a *Analyzer) doTaintedArgsFlowToReturn(call *ssa.Call, callee *ssa.Functio
 	return false
 }
 
+// callDepthExceeded reports whether the search path already follows as many
+// calls as the configured MaxCallDepth allows.
+func (a *Analyzer) callDepthExceeded() bool {
+	return a.config.MaxCallDepth > 0 && a.callDepth >= a.config.MaxCallDepth
+}
+
 // valueReachableFromParams checks if a value in a function is data-derived from
 // any of the specified parameters. This is a lightweight reachability check
 // within a single function body.
@@ -1390,11 +3176,15 @@ func (a *Analyzer) valueReachableFromParams(v ssa.Value, taintedParams map[*ssa.
 	}
 	visited[v] = true
 
+	// Constant values, such as calls to helpers returning a constant, do not
+	// depend on the parameters whatever their arguments
+	if a.constants.isConstant(v) {
+		return false
+	}
+
 	switch val := v.(type) {
 	case *ssa.Parameter:
 		return taintedParams[val]
-	case *ssa.Const:
-		return false
 	case *ssa.Global:
 		return false
 	case *ssa.Alloc:
@@ -1420,6 +3210,14 @@ func (a *Analyzer) valueReachableFromParams(v ssa.Value, taintedParams map[*ssa.
 					}
 				}
 			}
+			// And element stores (for array allocs, e.g. variadic append arguments)
+			if indexAddr, ok := ref.(*ssa.IndexAddr); ok {
+				for _, v := range storesTo(indexAddr) {
+					if a.valueReachableFromParams(v, taintedParams, visited, depth+1) {
+						return true
+					}
+				}
+			}
 		}
 		return false
 	case *ssa.Call:
@@ -1467,6 +3265,12 @@ func (a *Analyzer) valueReachableFromParams(v ssa.Value, taintedParams map[*ssa.
 		return false // Conservative: closures don't flow from params
 	case *ssa.Lookup:
 		return a.valueReachableFromParams(val.X, taintedParams, visited, depth+1)
+	case *ssa.Index:
+		return a.valueReachableFromParams(val.X, taintedParams, visited, depth+1)
+	case *ssa.Next:
+		return a.valueReachableFromParams(val.Iter, taintedParams, visited, depth+1)
+	case *ssa.Range:
+		return a.valueReachableFromParams(val.X, taintedParams, visited, depth+1)
 	default:
 		return false // Unknown SSA type — conservative, don't propagate
 	}
@@ -236,7 +236,7 @@ func handler(w http.ResponseWriter, r *http.Request) {
 }
 `}, 1, gosec.NewConfig()},
 
-	// Note: nested goroutines are not detected by current implementation
+	// Vulnerable: context.Background in a goroutine nested inside another goroutine
 	{[]string{`
 package main
 
@@ -254,6 +254,33 @@ func handler(r *http.Request) {
 		}()
 	}()
 }
+`}, 1, gosec.NewConfig()},
+
+	// Safe: request context propagated into a nested goroutine
+	{[]string{`
+package main
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+func poll(ctx context.Context) {
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+	}
+}
+
+func handler(r *http.Request) {
+	ctx := r.Context()
+	go func() {
+		go func() {
+			poll(ctx)
+		}()
+	}()
+}
 `}, 0, gosec.NewConfig()},
 
 	// Vulnerable: function parameter ignored in goroutine
@@ -274,7 +301,7 @@ func worker(ctx context.Context) {
 }
 `}, 2, gosec.NewConfig()},
 
-	// Note: channel range loops are not detected as blocking by current implementation
+	// Vulnerable: channel range loop blocks until the sender closes the channel
 	{[]string{`
 package main
 
@@ -286,9 +313,45 @@ func consume(ctx context.Context, ch <-chan int) {
 		_ = val
 	}
 }
+`}, 1, gosec.NewConfig()},
+
+	// Safe: channel range loop that also selects on ctx.Done
+	{[]string{`
+package main
+
+import "context"
+
+func consume(ctx context.Context, ch <-chan int) int {
+	total := 0
+	for val := range ch {
+		select {
+		case <-ctx.Done():
+			return total
+		default:
+		}
+		total += val
+	}
+	return total
+}
+`}, 0, gosec.NewConfig()},
+
+	// Safe: range over a slice does not block
+	{[]string{`
+package main
+
+import "context"
+
+func sum(ctx context.Context, vals []int) int {
+	_ = ctx
+	total := 0
+	for _, val := range vals {
+		total += val
+	}
+	return total
+}
 `}, 0, gosec.NewConfig()},
 
-	// Note: select loops without ctx.Done are not detected by current implementation
+	// Vulnerable: infinite select loop without a ctx.Done case
 	{[]string{`
 package main
 
@@ -306,6 +369,52 @@ func selectLoop(ctx context.Context, ch <-chan int) {
 		}
 	}
 }
+`}, 1, gosec.NewConfig()},
+
+	// Safe: infinite select loop with a ctx.Done case
+	{[]string{`
+package main
+
+import (
+	"context"
+	"time"
+)
+
+func selectLoop(ctx context.Context, ch <-chan int) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ch:
+		case <-time.After(time.Second):
+		}
+	}
+}
+`}, 0, gosec.NewConfig()},
+
+	// Safe: Done channel obtained before the loop; the select breaks out
+	// of the loop through a label instead of returning
+	{[]string{`
+package main
+
+import (
+	"context"
+	"fmt"
+)
+
+func drain(ctx context.Context, ch <-chan int) {
+	done := ctx.Done()
+loop:
+	for {
+		select {
+		case <-done:
+			break loop
+		case v := <-ch:
+			fmt.Println(v)
+		}
+	}
+	fmt.Println("stopped")
+}
 `}, 0, gosec.NewConfig()},
 
 	// Vulnerable: multiple context creations, one missing cancel
@@ -548,6 +657,62 @@ func spawnWorkers(ctx context.Context) {
 }
 `}, 1, gosec.NewConfig()},
 
+	// Vulnerable: unbounded loop launching goroutines without a limit
+	{[]string{`
+package main
+
+import "time"
+
+func work() {
+	time.Sleep(time.Millisecond)
+}
+
+func spawnForever() {
+	for {
+		go work()
+	}
+}
+`}, 1, gosec.NewConfig()},
+
+	// Safe: bounded loop launching a fixed number of goroutines
+	{[]string{`
+package main
+
+import "time"
+
+func work() {
+	time.Sleep(time.Millisecond)
+}
+
+func spawnN(n int) {
+	for i := 0; i < n; i++ {
+		go work()
+	}
+}
+`}, 0, gosec.NewConfig()},
+
+	// Safe: unbounded loop limited by a semaphore channel
+	{[]string{`
+package main
+
+import "time"
+
+func work() {
+	time.Sleep(time.Millisecond)
+}
+
+func spawnLimited() {
+	sem := make(chan struct{}, 10)
+	for {
+		sem <- struct{}{}
+		go func() {
+			defer func() { <-sem }()
+			work()
+		}()
+	}
+}
+`}, 0, gosec.NewConfig()},
+
 	// Vulnerable: loop with defer that has blocking call
 	{[]string{`
 package main
@@ -1871,5 +2036,627 @@ func setup() {
 		defer cancel()
 	}()
 }
+`}, 0, gosec.NewConfig()},
+
+	// Vulnerable: errgroup closure ignores the group context
+	{[]string{`
+package main
+
+import (
+	"context"
+	"net/http"
+
+	"golang.org/x/sync/errgroup"
+)
+
+func fetch(ctx context.Context, url string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+func fetchAll(ctx context.Context, urls []string) error {
+	g, gctx := errgroup.WithContext(ctx)
+	_ = gctx
+	for _, url := range urls {
+		g.Go(func() error {
+			return fetch(context.Background(), url)
+		})
+	}
+	return g.Wait()
+}
+`}, 1, gosec.NewConfig()},
+
+	// Safe: errgroup closure uses the group context and checks ctx.Done
+	{[]string{`
+package main
+
+import (
+	"context"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+)
+
+func pollAll(ctx context.Context, jobs []func() bool) error {
+	g, gctx := errgroup.WithContext(ctx)
+	for _, job := range jobs {
+		g.Go(func() error {
+			for !job() {
+				select {
+				case <-gctx.Done():
+					return gctx.Err()
+				case <-time.After(time.Second):
+				}
+			}
+			return nil
+		})
+	}
+	return g.Wait()
+}
+`}, 0, gosec.NewConfig()},
+
+	// Vulnerable: cancel stored in a field that is never called; a field with
+	// the same name on another type being called does not count
+	{[]string{`
+package main
+
+import "context"
+
+type Job struct {
+	cancel context.CancelFunc
+}
+
+type Other struct {
+	cancel context.CancelFunc
+}
+
+func (j *Job) Run(parent context.Context) {
+	_, cancel := context.WithCancel(parent)
+	j.cancel = cancel
+}
+
+func (o *Other) Close() {
+	o.cancel()
+}
+`}, 1, gosec.NewConfig()},
+
+	// Safe: cancel field copied to a local in Close before being called
+	{[]string{`
+package main
+
+import "context"
+
+type Pool struct {
+	stop context.CancelFunc
+}
+
+func (p *Pool) Start(parent context.Context) {
+	_, cancel := context.WithCancel(parent)
+	p.stop = cancel
+}
+
+func (p *Pool) Close() {
+	if stop := p.stop; stop != nil {
+		stop()
+	}
+}
+`}, 0, gosec.NewConfig()},
+
+	// Safe: cancel passed as a variadic argument to a cleanup registry
+	{[]string{`
+package main
+
+import "context"
+
+type registry struct {
+	fns []func()
+}
+
+func (r *registry) Add(fns ...func()) {
+	r.fns = append(r.fns, fns...)
+}
+
+var cleanup = &registry{}
+
+func start(ctx context.Context) context.Context {
+	child, cancel := context.WithCancel(ctx)
+	cleanup.Add(cancel)
+	return child
+}
+`}, 0, gosec.NewConfig()},
+
+	// Safe: cancel called by a finalizer registered with runtime.SetFinalizer
+	{[]string{`
+package main
+
+import (
+	"context"
+	"runtime"
+)
+
+type session struct {
+	ctx context.Context
+}
+
+func newSession(ctx context.Context) *session {
+	child, cancel := context.WithCancel(ctx)
+	s := &session{ctx: child}
+	runtime.SetFinalizer(s, func(*session) { cancel() })
+	return s
+}
+`}, 0, gosec.NewConfig()},
+
+	// Vulnerable: ticker is never stopped
+	{[]string{`
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+func heartbeat(n int) {
+	ticker := time.NewTicker(time.Second)
+	for i := 0; i < n; i++ {
+		fmt.Println(<-ticker.C)
+	}
+}
+`}, 1, gosec.NewConfig()},
+
+	// Safe: ticker stopped with defer
+	{[]string{`
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+func heartbeat(n int) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for i := 0; i < n; i++ {
+		fmt.Println(<-ticker.C)
+	}
+}
+`}, 0, gosec.NewConfig()},
+
+	// Vulnerable: timer abandoned when another case wins the select
+	{[]string{`
+package main
+
+import "time"
+
+func wait(done <-chan struct{}) bool {
+	timer := time.NewTimer(time.Minute)
+	select {
+	case <-timer.C:
+		return false
+	case <-done:
+		return true
+	}
+}
+`}, 1, gosec.NewConfig()},
+
+	// Safe: ticker stored in a struct and stopped by Close
+	{[]string{`
+package main
+
+import "time"
+
+type poller struct {
+	ticker *time.Ticker
+}
+
+func newPoller(interval time.Duration) *poller {
+	return &poller{ticker: time.NewTicker(interval)}
+}
+
+func (p *poller) Close() {
+	p.ticker.Stop()
+}
+`}, 0, gosec.NewConfig()},
+
+	// Vulnerable: loop calling a helper that blocks on http.Get (no ctx.Done guard)
+	{[]string{`
+package main
+
+import (
+	"context"
+	"net/http"
+)
+
+func fetch(url string) {
+	resp, err := http.Get(url)
+	if err == nil {
+		resp.Body.Close()
+	}
+}
+
+func pollAPI(ctx context.Context) {
+	for {
+		fetch("https://api.example.com")
+	}
+}
+`}, 1, gosec.NewConfig()},
+
+	// Safe: loop calling a helper that blocks on http.Get, with a ctx.Done guard
+	{[]string{`
+package main
+
+import (
+	"context"
+	"net/http"
+)
+
+func fetch(url string) {
+	resp, err := http.Get(url)
+	if err == nil {
+		resp.Body.Close()
+	}
+}
+
+func pollAPI(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+		fetch("https://api.example.com")
+	}
+}
+`}, 0, gosec.NewConfig()},
+
+	// Safe: loop exits when a helper consulting ctx.Err() says so
+	{[]string{`
+package main
+
+import (
+	"context"
+	"net/http"
+)
+
+func shouldStop(ctx context.Context) bool {
+	return ctx.Err() != nil
+}
+
+func pollAPI(ctx context.Context) {
+	for {
+		if shouldStop(ctx) {
+			return
+		}
+		resp, err := http.Get("https://api.example.com")
+		if err == nil {
+			resp.Body.Close()
+		}
+	}
+}
+`}, 0, gosec.NewConfig()},
+
+	// Vulnerable: loop calls a helper that ignores ctx and never exits
+	{[]string{`
+package main
+
+import (
+	"context"
+	"log"
+	"net/http"
+)
+
+func checkpoint(n int) {
+	log.Printf("poll %d", n)
+}
+
+func pollAPI(ctx context.Context) {
+	for n := 0; ; n++ {
+		checkpoint(n)
+		resp, err := http.Get("https://api.example.com")
+		if err == nil {
+			resp.Body.Close()
+		}
+	}
+}
+`}, 1, gosec.NewConfig()},
+
+	// Vulnerable: goroutine sends on an unbuffered channel nothing receives from
+	{[]string{`
+package main
+
+import "net/http"
+
+func fetchAsync(url string) {
+	errs := make(chan error)
+	go func() {
+		resp, err := http.Get(url)
+		if err == nil {
+			resp.Body.Close()
+		}
+		errs <- err
+	}()
+}
+`}, 1, gosec.NewConfig()},
+
+	// Vulnerable: goroutine started from a named function sends on an
+	// unbuffered channel nothing receives from
+	{[]string{`
+package main
+
+import "net/http"
+
+func fetch(url string, errs chan<- error) {
+	_, err := http.Get(url)
+	errs <- err
+}
+
+func fetchAsync(url string) {
+	errs := make(chan error)
+	go fetch(url, errs)
+	close(errs)
+}
+`}, 1, gosec.NewConfig()},
+
+	// Safe: the result sent by the goroutine is received
+	{[]string{`
+package main
+
+import "net/http"
+
+func fetchAsync(url string) error {
+	errs := make(chan error)
+	go func() {
+		resp, err := http.Get(url)
+		if err == nil {
+			resp.Body.Close()
+		}
+		errs <- err
+	}()
+	return <-errs
+}
+`}, 0, gosec.NewConfig()},
+
+	// Safe: buffered channel, the send never blocks
+	{[]string{`
+package main
+
+import "net/http"
+
+func fetchAsync(url string) {
+	errs := make(chan error, 1)
+	go func() {
+		_, err := http.Get(url)
+		errs <- err
+	}()
+}
+`}, 0, gosec.NewConfig()},
+
+	// Safe: channel handed to another function, which may receive from it
+	{[]string{`
+package main
+
+import "net/http"
+
+func fetchAsync(url string, collect func(<-chan error)) {
+	errs := make(chan error)
+	go func() {
+		_, err := http.Get(url)
+		errs <- err
+	}()
+	collect(errs)
+}
+`}, 0, gosec.NewConfig()},
+
+	// Vulnerable: loop dialing with net.Dial, which cannot be canceled
+	{[]string{`
+package main
+
+import (
+	"context"
+	"net"
+)
+
+func worker(ctx context.Context, addr string) {
+	for {
+		conn, err := net.Dial("tcp", addr)
+		if err != nil {
+			continue
+		}
+		conn.Close()
+	}
+}
+`}, 1, gosec.NewConfig()},
+
+	// Safe: loop dialing with DialContext and a ctx.Done guard
+	{[]string{`
+package main
+
+import (
+	"context"
+	"net"
+)
+
+func worker(ctx context.Context, addr string) {
+	var dialer net.Dialer
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+		conn, err := dialer.DialContext(ctx, "tcp", addr)
+		if err != nil {
+			continue
+		}
+		conn.Close()
+	}
+}
+`}, 0, gosec.NewConfig()},
+
+	// Vulnerable: early return before the cancel function is deferred
+	{[]string{`
+package main
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+func fetch(parent context.Context, key string) error {
+	ctx, cancel := context.WithTimeout(parent, time.Second)
+	if key == "" {
+		return errors.New("empty key")
+	}
+	defer cancel()
+	<-ctx.Done()
+	return ctx.Err()
+}
+`}, 1, gosec.NewConfig()},
+
+	// Safe: cancel function deferred before any early return
+	{[]string{`
+package main
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+func fetch(parent context.Context, key string) error {
+	ctx, cancel := context.WithTimeout(parent, time.Second)
+	defer cancel()
+	if key == "" {
+		return errors.New("empty key")
+	}
+	<-ctx.Done()
+	return ctx.Err()
+}
+`}, 0, gosec.NewConfig()},
+
+	// Safe: cancel function called on the early return and handed to the caller
+	// on the other one
+	{[]string{`
+package main
+
+import (
+	"context"
+	"errors"
+)
+
+func start(parent context.Context, name string) (context.Context, context.CancelFunc, error) {
+	ctx, cancel := context.WithCancel(parent)
+	if name == "" {
+		cancel()
+		return nil, nil, errors.New("empty name")
+	}
+	return ctx, cancel, nil
+}
+`}, 0, gosec.NewConfig()},
+
+	// Vulnerable: handler method of a struct wrapping the request starts a
+	// goroutine with context.Background
+	{[]string{`
+package main
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+type requestContext struct {
+	w   http.ResponseWriter
+	req *http.Request
+}
+
+func (c *requestContext) refresh() {
+	ctx := c.req.Context()
+	_ = ctx
+	go func() {
+		child, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		_ = child
+	}()
+}
+`}, 1, gosec.NewConfig()},
+
+	// Vulnerable: handler queries with context.Background despite having the
+	// request context, with request_background enabled
+	{[]string{`
+package main
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+)
+
+var db *sql.DB
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	_ = context.Background
+	rows, err := db.QueryContext(context.Background(), "SELECT name FROM users")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer rows.Close()
+}
+`}, 1, gosec.Config{"G118": map[string]interface{}{"request_background": true}}},
+
+	// Safe: handler queries with the request context, with request_background
+	// enabled
+	{[]string{`
+package main
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+)
+
+var db *sql.DB
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	_ = context.Background
+	rows, err := db.QueryContext(r.Context(), "SELECT name FROM users")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer rows.Close()
+}
+`}, 0, gosec.Config{"G118": map[string]interface{}{"request_background": true}}},
+
+	// Safe: context.Background in a handler is only reported when
+	// request_background is enabled
+	{[]string{`
+package main
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+)
+
+var db *sql.DB
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	_ = context.Background
+	rows, err := db.QueryContext(context.Background(), "SELECT name FROM users")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer rows.Close()
+}
 `}, 0, gosec.NewConfig()},
 }
@@ -164,9 +164,7 @@ func handler(db *sql.DB, r *http.Request) {
 }
 `}, 1, gosec.NewConfig()},
 
-	// Field tracking test 6: Nested struct field access
-	// Note: Current implementation doesn't track nested field paths (req.Query.SQL)
-	// This test documents the limitation - should be 1 issue but detects 0
+	// Field tracking test 6: Nested struct field access (req.Query.SQL)
 	{[]string{`
 package main
 
@@ -187,7 +185,7 @@ func handler(db *sql.DB, r *http.Request) {
 	req := &Request{Query: &Query{SQL: r.FormValue("input")}}
 	db.Query(req.Query.SQL)
 }
-`}, 0, gosec.NewConfig()},
+`}, 1, gosec.NewConfig()},
 
 	// Field tracking test 7: Field taint through control flow merge (tests Phi nodes)
 	{[]string{`
@@ -298,7 +296,8 @@ func handler(db *sql.DB, r *http.Request) {
 }
 `}, 1, gosec.NewConfig()},
 
-	// Test 13: Extract from tuple (multi-value return) with error handling
+	// Test 13: Extract from tuple (multi-value return) with error handling.
+	// The Atoi+Itoa round trip yields a plain integer, which sanitizes the value.
 	{[]string{`
 package main
 
@@ -315,7 +314,7 @@ func handler(db *sql.DB, r *http.Request) {
 		db.Query(query)
 	}
 }
-`}, 1, gosec.NewConfig()},
+`}, 0, gosec.NewConfig()},
 
 	// Test 14: Phi node with loop (tests Phi taint propagation in loops)
 	{[]string{`
@@ -334,6 +333,50 @@ func handler(db *sql.DB, r *http.Request) {
 	}
 	db.Query("SELECT * FROM data WHERE value = '" + result + "'")
 }
+`}, 1, gosec.NewConfig()},
+
+	// Test 14b: Loop-carried Phi nodes that only ever hold constants, in a
+	// function that has a tainted request
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+func handler(db *sql.DB, r *http.Request) {
+	count := len(r.FormValue("filters"))
+	query := "SELECT * FROM data WHERE 1 = 1"
+	column, other := "name", "email"
+	for i := 0; i < count; i++ {
+		query += " AND " + column + " IS NOT NULL"
+		column, other = other, column
+	}
+	db.Query(query)
+}
+`}, 0, gosec.NewConfig()},
+
+	// Test 14c: Loop-carried Phi node merging a tainted branch
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+func handler(db *sql.DB, r *http.Request) {
+	query := "SELECT * FROM data WHERE 1 = 1"
+	for i := 0; i < 3; i++ {
+		if i == 2 {
+			query += " AND value = '" + r.FormValue("value") + "'"
+		} else {
+			query += " AND id > 0"
+		}
+	}
+	db.Query(query)
+}
 `}, 1, gosec.NewConfig()},
 
 	// Test 15: UnOp dereference (tests UnOp taint propagation)
@@ -413,6 +456,74 @@ func handler(db *sql.DB, r *http.Request) {
 	query := buildQuery(name)
 	db.Query(query)
 }
+`}, 1, gosec.NewConfig()},
+
+	// Test 18b: Same flow with the helper in another file of the package
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+func handler(db *sql.DB, r *http.Request) {
+	name := r.FormValue("name")
+	query := buildQuery(name)
+	db.Query(query)
+}
+`, `
+package main
+
+func buildQuery(userInput string) string {
+	return "SELECT * FROM users WHERE name = '" + userInput + "'"
+}
+`}, 1, gosec.NewConfig()},
+
+	// Test 18c: Helper in another file that drops its argument
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+func handler(db *sql.DB, r *http.Request) {
+	name := r.FormValue("name")
+	query := buildQuery(name)
+	db.Query(query, name)
+}
+`, `
+package main
+
+func buildQuery(userInput string) string {
+	_ = userInput
+	return "SELECT * FROM users WHERE name = ?"
+}
+`}, 0, gosec.NewConfig()},
+
+	// Test 18d: Source in one file reaching a sink in another one
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+func handler(db *sql.DB, r *http.Request) {
+	lookup(db, r.URL.Query().Get("name"))
+}
+`, `
+package main
+
+import "database/sql"
+
+func lookup(db *sql.DB, name string) {
+	rows, _ := db.Query("SELECT * FROM users WHERE name = '" + name + "'")
+	_ = rows
+}
 `}, 1, gosec.NewConfig()},
 
 	// Test 19: Parameter through variable assignment
@@ -709,8 +820,7 @@ func handler(db *sql.DB, r *http.Request) {
 `}, 1, gosec.NewConfig()},
 
 	// Test 32: Parameter through map Lookup in helper
-	// Note: Current implementation doesn't track taint through map values
-	// Map literal with tainted value → map lookup doesn't propagate taint
+	// Map literal with tainted value → map lookup propagates taint
 	{[]string{`
 package main
 
@@ -729,7 +839,7 @@ func handler(db *sql.DB, r *http.Request) {
 	value := lookupValue(data, "user")
 	db.Query("SELECT * FROM users WHERE id = '" + value + "'")
 }
-`}, 0, gosec.NewConfig()},
+`}, 1, gosec.NewConfig()},
 
 	// Test 33: Parameter through complex Alloc with multiple stores
 	{[]string{`
@@ -902,8 +1012,7 @@ func handler(db *sql.DB, r *http.Request) {
 }
 `}, 1, gosec.NewConfig()},
 
-	// Test 40: Parameter through nested FieldAddr in struct
-	// Note: Nested field paths (outer.Inner.Value) not fully tracked
+	// Test 40: Parameter through nested FieldAddr in struct (outer.Inner.Value)
 	{[]string{`
 package main
 
@@ -931,7 +1040,7 @@ func handler(db *sql.DB, r *http.Request) {
 	outer := buildNested(input)
 	db.Query("SELECT * FROM data WHERE value = '" + outer.Inner.Value + "'")
 }
-`}, 0, gosec.NewConfig()},
+`}, 1, gosec.NewConfig()},
 
 	// Test 41: Parameter through Slice with multiple elements
 	{[]string{`
@@ -1027,6 +1136,64 @@ func handler(db *sql.DB, r *http.Request) {
 }
 `}, 1, gosec.NewConfig()},
 
+	// Test 44b: Tainted value spread again from a variadic parameter into a
+	// helper that concatenates one of the elements into SQL
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+func whereAll(db *sql.DB, values ...interface{}) {
+	query := "SELECT * FROM logs WHERE 1 = 1"
+	for _, v := range values {
+		if s, ok := v.(string); ok {
+			query += " AND entry = '" + s + "'"
+		}
+	}
+	db.Query(query)
+}
+
+func forward(db *sql.DB, values ...interface{}) {
+	whereAll(db, values...)
+}
+
+func handler(db *sql.DB, r *http.Request) {
+	forward(db, "static", r.FormValue("value"), 42)
+}
+`}, 1, gosec.NewConfig()},
+
+	// Test 44c: Same helpers with only constant variadic arguments
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+)
+
+func whereAll(db *sql.DB, values ...interface{}) {
+	query := "SELECT * FROM logs WHERE 1 = 1"
+	for _, v := range values {
+		if s, ok := v.(string); ok {
+			query += " AND entry = '" + s + "'"
+		}
+	}
+	db.Query(query)
+}
+
+func forward(db *sql.DB, values ...interface{}) {
+	whereAll(db, values...)
+}
+
+func handler(db *sql.DB) {
+	forward(db, "static", "other", 42)
+	values := []interface{}{"static"}
+	whereAll(db, values...)
+}
+`}, 0, gosec.NewConfig()},
+
 	// Test 45: Parameter through nested Call chains
 	{[]string{`
 package main
@@ -1254,7 +1421,6 @@ func handler(db *sql.DB, r *http.Request) {
 `}, 0, gosec.NewConfig()},
 
 	// Lookup operation (map access)
-	// NOTE: Map value taint tracking not yet supported - documented limitation
 	{[]string{`
 package main
 
@@ -1270,7 +1436,7 @@ func handler(db *sql.DB, r *http.Request) {
 	query := "SELECT * FROM users WHERE name = '" + userInputs["query"] + "'"
 	db.Query(query)
 }
-`}, 0, gosec.NewConfig()},
+`}, 1, gosec.NewConfig()},
 
 	// Type assertion with tainted data
 	{[]string{`
@@ -1445,7 +1611,6 @@ func handler(db *sql.DB, r *http.Request) {
 `}, 1, gosec.NewConfig()},
 
 	// Global variable with tainted data
-	// NOTE: Global variable taint tracking not yet supported - documented limitation
 	{[]string{`
 package main
 
@@ -1464,7 +1629,7 @@ func handler(db *sql.DB, r *http.Request) {
 func executeQuery(db *sql.DB) {
 	db.Query(globalQuery)
 }
-`}, 0, gosec.NewConfig()},
+`}, 1, gosec.NewConfig()},
 
 	// Complex Phi node - multiple branches converging
 	{[]string{`
@@ -1551,10 +1716,9 @@ func executeQueryBuilder(db *sql.DB, qb *QueryBuilder) {
 	query := "SELECT * FROM users WHERE " + qb.Filter
 	db.Query(query)
 }
-`}, 0, gosec.NewConfig()}, // NOTE: Some advanced patterns have limitations
+`}, 1, gosec.NewConfig()},
 
 	// Global struct with tainted field
-	// NOTE: Global variable taint tracking not yet supported - documented limitation
 	{[]string{`
 package main
 
@@ -1578,7 +1742,7 @@ func search(db *sql.DB) {
 	query := "SELECT * FROM products WHERE name LIKE '%" + appConfig.SearchTerm + "%'"
 	db.Query(query)
 }
-`}, 0, gosec.NewConfig()},
+`}, 1, gosec.NewConfig()},
 
 	// Complex Phi with nested conditionals
 	{[]string{`
@@ -1703,7 +1867,6 @@ func handler(db *sql.DB, r *http.Request) {
 `}, 1, gosec.NewConfig()},
 
 	// Multiple globals with taint propagation
-	// NOTE: Global variable taint tracking not yet supported - documented limitation
 	{[]string{`
 package main
 
@@ -1727,7 +1890,7 @@ func executeGlobalQuery(db *sql.DB) {
 	query := "SELECT * FROM " + globalTable + " WHERE " + globalFilter
 	db.Query(query)
 }
-`}, 0, gosec.NewConfig()},
+`}, 1, gosec.NewConfig()},
 
 	// Interprocedural with variadic function
 	{[]string{`
@@ -1873,8 +2036,8 @@ execute:
 }
 `}, 1, gosec.NewConfig()},
 
-	// Interprocedural with interface implementation
-	// NOTE: Interface method taint tracking not yet fully supported - documented limitation
+	// Interprocedural with interface implementation: the tainted argument of
+	// the interface method call reaches the sink in its only implementation
 	{[]string{`
 package main
 
@@ -1900,7 +2063,7 @@ func handler(db *sql.DB, r *http.Request) {
 	var executor QueryExecutor = &SimpleExecutor{}
 	executor.Execute(db, query)
 }
-`}, 0, gosec.NewConfig()},
+`}, 1, gosec.NewConfig()},
 
 	// Multiple Phi nodes with complex control flow
 	{[]string{`
@@ -2195,5 +2358,2240 @@ func handler(db *sql.DB, _ *http.Request) {
 	query := "SELECT * FROM t WHERE host = '" + svc.cfg.Host + "'"
 	db.Query(query)
 }
+`}, 0, gosec.NewConfig()},
+
+	// Environment variable concatenated into a query via os.Getenv
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"os"
+)
+
+func listRows(db *sql.DB) {
+	db.Query("SELECT * FROM " + os.Getenv("TABLE"))
+}
+`}, 1, gosec.NewConfig()},
+
+	// Environment variable from os.LookupEnv concatenated into a query
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"os"
+)
+
+func listRows(db *sql.DB) {
+	tenant, ok := os.LookupEnv("TENANT")
+	if !ok {
+		return
+	}
+	db.Query("SELECT * FROM accounts WHERE tenant = '" + tenant + "'")
+}
+`}, 1, gosec.NewConfig()},
+
+	// Safe: environment variable bound as a query parameter
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"os"
+)
+
+func listRows(db *sql.DB) {
+	tenant, _ := os.LookupEnv("TENANT")
+	db.Query("SELECT * FROM accounts WHERE tenant = ?", tenant)
+	db.Query("SELECT * FROM accounts WHERE owner = ?", os.Getenv("OWNER"))
+}
+`}, 0, gosec.NewConfig()},
+
+	// Command-line argument indexed from os.Args
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"os"
+)
+
+func purge(db *sql.DB) {
+	db.Exec("DELETE FROM jobs WHERE id = " + os.Args[1])
+}
+`}, 1, gosec.NewConfig()},
+
+	// Dereferenced *string returned by flag.String
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"flag"
+)
+
+func purge(db *sql.DB) {
+	table := flag.String("table", "jobs", "table to purge")
+	flag.Parse()
+	db.Exec("DELETE FROM " + *table)
+}
+`}, 1, gosec.NewConfig()},
+
+	// Variable bound through flag.StringVar and positional flag.Arg
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"flag"
+)
+
+func purge(db *sql.DB) {
+	var owner string
+	flag.StringVar(&owner, "owner", "", "job owner")
+	flag.Parse()
+	db.Exec("DELETE FROM jobs WHERE owner = '" + owner + "'")
+	db.Exec("DELETE FROM jobs WHERE id = " + flag.Arg(0))
+}
+`}, 2, gosec.NewConfig()},
+
+	// Safe: flag value bound as a placeholder argument
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"flag"
+	"os"
+)
+
+func purge(db *sql.DB) {
+	owner := flag.String("owner", "", "job owner")
+	flag.Parse()
+	db.Exec("DELETE FROM jobs WHERE owner = ?", *owner)
+	db.Exec("DELETE FROM jobs WHERE id = ?", os.Args[1])
+}
+`}, 0, gosec.NewConfig()},
+
+	// Line scanned from standard input with bufio.Scanner
+	{[]string{`
+package main
+
+import (
+	"bufio"
+	"database/sql"
+	"os"
+)
+
+func interactive(db *sql.DB) {
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		db.Query("SELECT * FROM users WHERE name = '" + scanner.Text() + "'")
+	}
+}
+`}, 1, gosec.NewConfig()},
+
+	// Line read from standard input with bufio.Reader.ReadString
+	{[]string{`
+package main
+
+import (
+	"bufio"
+	"database/sql"
+	"os"
+)
+
+func interactive(db *sql.DB) {
+	reader := bufio.NewReader(os.Stdin)
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return
+	}
+	db.Query("SELECT * FROM users WHERE name = '" + line + "'")
+}
+`}, 1, gosec.NewConfig()},
+
+	// Safe: scanned standard input bound as a placeholder argument
+	{[]string{`
+package main
+
+import (
+	"bufio"
+	"database/sql"
+	"os"
+)
+
+func interactive(db *sql.DB) {
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		db.Query("SELECT * FROM users WHERE name = ?", scanner.Text())
+	}
+}
+`}, 0, gosec.NewConfig()},
+
+	// Safe: scanner over a constant string reader
+	{[]string{`
+package main
+
+import (
+	"bufio"
+	"database/sql"
+	"strings"
+)
+
+func seed(db *sql.DB) {
+	scanner := bufio.NewScanner(strings.NewReader("alice\nbob"))
+	for scanner.Scan() {
+		db.Query("SELECT * FROM users WHERE name = '" + scanner.Text() + "'")
+	}
+}
+`}, 0, gosec.NewConfig()},
+
+	// File contents from os.ReadFile converted and concatenated into a query
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"os"
+)
+
+func loadFilter(db *sql.DB) {
+	data, err := os.ReadFile("/etc/app/filter.sql")
+	if err != nil {
+		return
+	}
+	db.Query("SELECT * FROM events WHERE " + string(data))
+}
+`}, 1, gosec.NewConfig()},
+
+	// File contents from ioutil.ReadFile concatenated into a query
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"io/ioutil"
+)
+
+func loadFilter(db *sql.DB, path string) {
+	data, _ := ioutil.ReadFile(path)
+	filter := string(data)
+	db.Exec("DELETE FROM events WHERE " + filter)
+}
+`}, 1, gosec.NewConfig()},
+
+	// Safe: file contents only used as a bound parameter
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"os"
+)
+
+func loadFilter(db *sql.DB) {
+	data, err := os.ReadFile("/etc/app/filter.txt")
+	if err != nil {
+		return
+	}
+	db.Query("SELECT ?", string(data))
+}
+`}, 0, gosec.NewConfig()},
+
+	// Request header value concatenated into a query
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+func handler(db *sql.DB, r *http.Request) {
+	tenant := r.Header.Get("X-Tenant")
+	db.Query("SELECT * FROM accounts WHERE tenant = '" + tenant + "'")
+}
+`}, 1, gosec.NewConfig()},
+
+	// Header map index access and cookie value concatenated into queries
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+func handler(db *sql.DB, r *http.Request) {
+	if vals := r.Header["X-Tenant"]; len(vals) > 0 {
+		db.Query("SELECT * FROM accounts WHERE tenant = '" + vals[0] + "'")
+	}
+	c, err := r.Cookie("sid")
+	if err != nil {
+		return
+	}
+	db.Query("SELECT * FROM sessions WHERE id = '" + c.Value + "'")
+}
+`}, 2, gosec.NewConfig()},
+
+	// Header and cookie received directly by exported entry points
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+func AuditTenant(db *sql.DB, h http.Header) {
+	db.Exec("INSERT INTO audit VALUES ('" + h.Get("X-Tenant") + "')")
+}
+
+func AuditSession(db *sql.DB, c *http.Cookie) {
+	db.Exec("INSERT INTO audit VALUES ('" + c.Value + "')")
+}
+`}, 2, gosec.NewConfig()},
+
+	// Safe: header value used as a bound parameter
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+func handler(db *sql.DB, r *http.Request) {
+	db.Query("SELECT * FROM accounts WHERE tenant = ?", r.Header.Get("X-Tenant"))
+	if c, err := r.Cookie("sid"); err == nil {
+		db.Query("SELECT * FROM sessions WHERE id = ?", c.Value)
+	}
+}
+`}, 0, gosec.NewConfig()},
+	// Tainted value inserted by index assignment, read back by key
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+func handler(db *sql.DB, r *http.Request) {
+	filters := make(map[string]string)
+	filters["status"] = "active"
+	filters["owner"] = r.URL.Query().Get("owner")
+	db.Query("SELECT * FROM tasks WHERE status = '" + filters["status"] + "'")
+}
+`}, 1, gosec.NewConfig()},
+
+	// Safe: map holding only constant values
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+func lookupValue(m map[string]string, key string) string {
+	return m[key]
+}
+
+func handler(db *sql.DB, r *http.Request) {
+	columns := map[string]string{"name": "name", "date": "created_at"}
+	column := lookupValue(columns, r.FormValue("sort"))
+	db.Query("SELECT * FROM users ORDER BY " + column)
+}
+`}, 0, gosec.NewConfig()},
+	// Three-level field path with the tainted value at the deepest level
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+type Clause struct {
+	SQL string
+}
+
+type Filter struct {
+	Where *Clause
+}
+
+type Search struct {
+	Filter *Filter
+}
+
+func handler(db *sql.DB, r *http.Request) {
+	s := &Search{Filter: &Filter{Where: &Clause{SQL: r.FormValue("where")}}}
+	db.Query("SELECT * FROM items WHERE " + s.Filter.Where.SQL)
+}
+`}, 1, gosec.NewConfig()},
+
+	// Nested struct embedded by value
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+type Query struct {
+	SQL   string
+	Limit string
+}
+
+type Request struct {
+	Query Query
+}
+
+func handler(db *sql.DB, r *http.Request) {
+	req := &Request{Query: Query{SQL: r.FormValue("input"), Limit: "10"}}
+	db.Query(req.Query.SQL)
+}
+`}, 1, gosec.NewConfig()},
+
+	// Safe: nested path whose leaf only holds constants while a sibling is tainted
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+type Query struct {
+	SQL   string
+	Label string
+}
+
+type Request struct {
+	Query *Query
+	Owner string
+}
+
+func handler(db *sql.DB, r *http.Request) {
+	req := &Request{
+		Query: &Query{SQL: "SELECT * FROM items", Label: r.FormValue("label")},
+		Owner: r.FormValue("owner"),
+	}
+	db.Query(req.Query.SQL)
+}
+`}, 0, gosec.NewConfig()},
+
+	// Safe: value-embedded nested struct with a constant leaf
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+type Query struct {
+	SQL   string
+	Label string
+}
+
+type Request struct {
+	Query Query
+}
+
+func handler(db *sql.DB, r *http.Request) {
+	req := &Request{Query: Query{SQL: "SELECT * FROM items", Label: r.FormValue("label")}}
+	db.Query(req.Query.SQL)
+}
+`}, 0, gosec.NewConfig()},
+	// Tainted value sent on a channel from a goroutine and received into a query
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+func handler(db *sql.DB, r *http.Request) {
+	names := make(chan string)
+	go func() {
+		names <- r.FormValue("name")
+	}()
+	name := <-names
+	db.Query("SELECT * FROM users WHERE name = '" + name + "'")
+}
+`}, 1, gosec.NewConfig()},
+
+	// Channel handed to a worker goroutine that builds the query
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+func worker(db *sql.DB, ids <-chan string) {
+	for id := range ids {
+		db.Exec("DELETE FROM sessions WHERE user_id = " + id)
+	}
+}
+
+func handler(db *sql.DB, r *http.Request) {
+	ids := make(chan string, 1)
+	go worker(db, ids)
+	ids <- r.URL.Query().Get("id")
+	close(ids)
+}
+`}, 1, gosec.NewConfig()},
+
+	// Tainted value received through a select statement
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+	"time"
+)
+
+func handler(db *sql.DB, r *http.Request) {
+	input := make(chan string, 1)
+	input <- r.FormValue("filter")
+	select {
+	case filter := <-input:
+		db.Query("SELECT * FROM items WHERE " + filter)
+	case <-time.After(time.Second):
+	}
+}
+`}, 1, gosec.NewConfig()},
+
+	// Safe: channel carrying only constants
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+func handler(db *sql.DB, r *http.Request) {
+	tables := make(chan string, 2)
+	go func() {
+		tables <- "users"
+		tables <- "orders"
+		close(tables)
+	}()
+	for table := range tables {
+		db.Query("SELECT COUNT(*) FROM " + table)
+	}
+	_ = r
+}
+`}, 0, gosec.NewConfig()},
+	// Tainted value stored in a package-level variable and queried elsewhere
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+var currentTenant string
+
+func setTenant(r *http.Request) {
+	currentTenant = r.FormValue("tenant")
+}
+
+func loadAccounts(db *sql.DB) {
+	db.Query("SELECT * FROM accounts WHERE tenant = '" + currentTenant + "'")
+}
+
+func handler(db *sql.DB, r *http.Request) {
+	setTenant(r)
+	loadAccounts(db)
+}
+`}, 1, gosec.NewConfig()},
+
+	// Safe: package-level variable only assigned constants
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+var tableName = "accounts"
+
+func useArchive() {
+	tableName = "accounts_archive"
+}
+
+func loadAccounts(db *sql.DB) {
+	db.Query("SELECT * FROM " + tableName)
+}
+
+func handler(db *sql.DB, r *http.Request) {
+	if r.FormValue("archive") == "1" {
+		useArchive()
+	}
+	loadAccounts(db)
+}
+`}, 0, gosec.NewConfig()},
+	// Recursive and mutually recursive query builders fed by tainted parameters
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+	"strings"
+)
+
+func buildFilter(terms []string, acc string) string {
+	if len(terms) == 0 {
+		return acc
+	}
+	if acc != "" {
+		acc += " OR "
+	}
+	return buildFilter(terms[1:], acc+"name = '"+terms[0]+"'")
+}
+
+func quoteEven(n int, s string) string {
+	if n == 0 {
+		return s
+	}
+	return quoteOdd(n-1, "'"+s)
+}
+
+func quoteOdd(n int, s string) string {
+	if n == 0 {
+		return s
+	}
+	return quoteEven(n-1, s+"'")
+}
+
+func handler(db *sql.DB, r *http.Request) {
+	terms := strings.Split(r.FormValue("q"), ",")
+	db.Query("SELECT * FROM items WHERE " + buildFilter(terms, ""))
+	db.Query("SELECT * FROM items WHERE name = " + quoteEven(2, r.FormValue("name")))
+}
+`}, 2, gosec.NewConfig()},
+
+	// Safe: recursive builder only fed constants
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+func repeat(s string, n int) string {
+	if n <= 1 {
+		return s
+	}
+	return s + ", " + repeat(s, n-1)
+}
+
+func handler(db *sql.DB, r *http.Request) {
+	db.Query("INSERT INTO items (a, b, c) VALUES (" + repeat("?", 3) + ")", r.FormValue("a"), r.FormValue("b"), r.FormValue("c"))
+}
+`}, 0, gosec.NewConfig()},
+	// Tainted element appended to a slice and joined into a query
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+	"strings"
+)
+
+func handler(db *sql.DB, r *http.Request) {
+	conditions := []string{"deleted = false"}
+	conditions = append(conditions, "owner = '"+r.FormValue("owner")+"'")
+	db.Query("SELECT * FROM docs WHERE " + strings.Join(conditions, " AND "))
+}
+`}, 1, gosec.NewConfig()},
+
+	// Tainted element assigned by index and appended in a helper before joining
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+	"strings"
+)
+
+func addCondition(conditions []string, c string) []string {
+	return append(conditions, c)
+}
+
+func handler(db *sql.DB, r *http.Request) {
+	columns := make([]string, 2)
+	columns[0] = "id"
+	columns[1] = r.FormValue("column")
+	conditions := addCondition(nil, "tag = '"+r.FormValue("tag")+"'")
+	db.Query("SELECT " + strings.Join(columns, ", ") + " FROM docs")
+	db.Query("SELECT * FROM docs WHERE " + strings.Join(conditions, " AND "))
+}
+`}, 2, gosec.NewConfig()},
+
+	// Safe: constant conditions joined, user input passed as a bound parameter
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+	"strings"
+)
+
+func handler(db *sql.DB, r *http.Request) {
+	conditions := []string{"deleted = false"}
+	conditions = append(conditions, "owner = ?")
+	db.Query("SELECT * FROM docs WHERE "+strings.Join(conditions, " AND "), r.FormValue("owner"))
+}
+`}, 0, gosec.NewConfig()},
+	// Tainted input written into a strings.Builder
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+	"strings"
+)
+
+func handler(db *sql.DB, r *http.Request) {
+	var b strings.Builder
+	b.WriteString("SELECT * FROM users WHERE name = '")
+	b.WriteString(r.FormValue("name"))
+	b.WriteByte('\'')
+	db.Query(b.String())
+}
+`}, 1, gosec.NewConfig()},
+
+	// Tainted input formatted into a bytes.Buffer
+	{[]string{`
+package main
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"net/http"
+)
+
+func handler(db *sql.DB, r *http.Request) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "DELETE FROM users WHERE id = %s", r.URL.Query().Get("id"))
+	db.Exec(buf.String())
+}
+`}, 1, gosec.NewConfig()},
+
+	// Tainted input written into a builder held in a struct field
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+	"strings"
+)
+
+type queryBuilder struct {
+	sql strings.Builder
+}
+
+func handler(db *sql.DB, r *http.Request) {
+	qb := &queryBuilder{}
+	qb.sql.WriteString("SELECT * FROM items ORDER BY ")
+	qb.sql.WriteString(r.FormValue("sort"))
+	db.Query(qb.sql.String())
+}
+`}, 1, gosec.NewConfig()},
+
+	// Safe: builder holding only constants, user input bound as a parameter
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+	"strings"
+)
+
+func handler(db *sql.DB, r *http.Request) {
+	var b strings.Builder
+	b.WriteString("SELECT * FROM users")
+	b.WriteString(" WHERE name = ?")
+	db.Query(b.String(), r.FormValue("name"))
+}
+`}, 0, gosec.NewConfig()},
+	// Safe: numeric conversions of user input sanitize the value
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+)
+
+func handler(db *sql.DB, r *http.Request) {
+	limit, err := strconv.ParseInt(r.FormValue("limit"), 10, 64)
+	if err != nil {
+		return
+	}
+	db.Query("SELECT * FROM items LIMIT " + strconv.FormatInt(limit, 10))
+	db.Query("SELECT * FROM items LIMIT " + strconv.Itoa(len(r.FormValue("q"))))
+}
+`}, 0, gosec.NewConfig()},
+
+	// Non-numeric strconv helpers keep the value tainted: Quote does not escape
+	// single quotes for SQL
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+)
+
+func handler(db *sql.DB, r *http.Request) {
+	db.Query("SELECT * FROM items WHERE name = " + strconv.Quote(r.FormValue("name")))
+}
+`}, 1, gosec.NewConfig()},
+	// Safe: column validated against an allowlist map before use in ORDER BY
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+)
+
+var sortableColumns = map[string]bool{"name": true, "created_at": true}
+
+func handler(db *sql.DB, r *http.Request) {
+	column := r.FormValue("sort")
+	if sortableColumns[column] {
+		db.Query("SELECT * FROM users ORDER BY " + column)
+	}
+	direction := r.FormValue("dir")
+	if _, ok := map[string]struct{}{"ASC": {}, "DESC": {}}[direction]; !ok {
+		return
+	}
+	db.Query(fmt.Sprintf("SELECT * FROM users ORDER BY name %s", direction))
+}
+`}, 0, gosec.NewConfig()},
+
+	// Safe: switch with constant cases, or mapping input to constants
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+func sortColumn(s string) string {
+	switch s {
+	case "date":
+		return "created_at"
+	default:
+		return "name"
+	}
+}
+
+func handler(db *sql.DB, r *http.Request) {
+	column := r.FormValue("sort")
+	switch column {
+	case "name", "created_at":
+		db.Query("SELECT * FROM users ORDER BY " + column)
+	}
+	db.Query("SELECT * FROM users ORDER BY " + sortColumn(r.FormValue("order")))
+}
+`}, 0, gosec.NewConfig()},
+
+	// Unvalidated column, and a check that does not restrict the value to the allowlist
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+var sortableColumns = map[string]bool{"name": true, "created_at": true}
+
+func handler(db *sql.DB, r *http.Request) {
+	column := r.FormValue("sort")
+	db.Query("SELECT * FROM users ORDER BY " + column)
+	if sortableColumns[column] || len(column) < 16 {
+		db.Query("SELECT * FROM users ORDER BY " + column)
+	}
+}
+`}, 2, gosec.NewConfig()},
+
+	// Package-level maps filled outside the package initializers are not allowlists
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+var allowed = map[string]bool{}
+
+func register(r *http.Request) {
+	allowed[r.FormValue("c")] = true
+}
+
+func handler(db *sql.DB, r *http.Request) {
+	register(r)
+	col := r.FormValue("c")
+	if allowed[col] {
+		db.Query("SELECT * FROM users ORDER BY " + col)
+	}
+}
+`}, 1, gosec.NewConfig()},
+
+	// Package-level maps filled with constant keys by init are allowlists
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+var allowed = map[string]bool{}
+
+func init() {
+	allowed["name"] = true
+	allowed["created_at"] = true
+}
+
+func handler(db *sql.DB, r *http.Request) {
+	col := r.FormValue("c")
+	if allowed[col] {
+		db.Query("SELECT * FROM users ORDER BY " + col)
+	}
+}
+`}, 0, gosec.NewConfig()},
+
+	// Maps filled from the input itself are not allowlists
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+func handler(db *sql.DB, r *http.Request) {
+	column := r.FormValue("sort")
+	allowed := map[string]bool{"name": true}
+	allowed[column] = true
+	if allowed[column] {
+		db.Query("SELECT * FROM users ORDER BY " + column)
+	}
+	direction := r.FormValue("dir")
+	seen := make(map[string]struct{})
+	seen[direction] = struct{}{}
+	if _, ok := seen[direction]; ok {
+		db.Query("SELECT * FROM users ORDER BY name " + direction)
+	}
+}
+`}, 2, gosec.NewConfig()},
+	// Context-aware query methods on *sql.DB, *sql.Tx and *sql.Conn
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+func handler(db *sql.DB, r *http.Request) {
+	ctx := r.Context()
+	name := r.URL.Query().Get("name")
+	db.QueryContext(ctx, "SELECT * FROM users WHERE name = '"+name+"'")
+	db.QueryRowContext(ctx, "SELECT id FROM users WHERE name = '"+name+"'")
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+	tx.ExecContext(ctx, "DELETE FROM users WHERE name = '"+name+"'")
+
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	conn.QueryContext(ctx, "SELECT * FROM audit WHERE actor = '"+name+"'")
+	conn.ExecContext(ctx, "INSERT INTO audit (actor) VALUES ('"+name+"')")
+}
+`}, 5, gosec.NewConfig()},
+
+	// Safe: context-aware query methods with placeholders
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+func handler(db *sql.DB, r *http.Request) {
+	ctx := r.Context()
+	name := r.URL.Query().Get("name")
+	db.QueryContext(ctx, "SELECT * FROM users WHERE name = ?", name)
+
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	conn.ExecContext(ctx, "INSERT INTO audit (actor) VALUES (?)", name)
+}
+`}, 0, gosec.NewConfig()},
+	// Tainted SQL text passed to Prepare: parameters bound later cannot make it safe
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+func handler(db *sql.DB, r *http.Request) {
+	stmt, err := db.Prepare("SELECT * FROM users WHERE name = '" + r.FormValue("name") + "'")
+	if err != nil {
+		return
+	}
+	defer stmt.Close()
+	stmt.Query()
+
+	tx, err := db.BeginTx(r.Context(), nil)
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+	txStmt, err := tx.PrepareContext(r.Context(), "DELETE FROM users WHERE id = "+r.FormValue("id"))
+	if err != nil {
+		return
+	}
+	defer txStmt.Close()
+	txStmt.Exec()
+}
+`}, 2, gosec.NewConfig()},
+
+	// Safe: constant SQL text passed to Prepare, user input bound as a parameter
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+func handler(db *sql.DB, r *http.Request) {
+	stmt, err := db.Prepare("SELECT * FROM users WHERE name = ?")
+	if err != nil {
+		return
+	}
+	defer stmt.Close()
+	stmt.Query(r.FormValue("name"))
+}
+`}, 0, gosec.NewConfig()},
+
+	// Vulnerable: range over a slice holding a form value
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+func handler(db *sql.DB, r *http.Request) {
+	params := []string{r.FormValue("name"), r.FormValue("city")}
+	query := "SELECT * FROM users WHERE "
+	for _, v := range params {
+		query += v
+	}
+	db.Query(query)
+}
+`}, 1, gosec.NewConfig()},
+
+	// Vulnerable: range over a map and an array holding form values
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+func byMap(db *sql.DB, r *http.Request) {
+	filters := map[string]string{"name": r.FormValue("name")}
+	query := "SELECT * FROM users WHERE "
+	for _, v := range filters {
+		query += v
+	}
+	db.Query(query)
+}
+
+func byArray(db *sql.DB, r *http.Request) {
+	filters := [2]string{r.FormValue("name"), "active = 1"}
+	query := "SELECT * FROM users WHERE "
+	for _, v := range filters {
+		query += v
+	}
+	db.Query(query)
+}
+`}, 2, gosec.NewConfig()},
+
+	// Vulnerable: range over the characters of a form value
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+func handler(db *sql.DB, r *http.Request) {
+	query := "SELECT * FROM users WHERE name = '"
+	for _, c := range r.FormValue("name") {
+		query += string(c)
+	}
+	db.Query(query + "'")
+}
+`}, 1, gosec.NewConfig()},
+
+	// Safe: range over a slice of constants
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+)
+
+func handler(db *sql.DB) {
+	columns := []string{"name", "city"}
+	query := "SELECT id"
+	for _, c := range columns {
+		query += ", " + c
+	}
+	db.Query(query + " FROM users")
+}
+`}, 0, gosec.NewConfig()},
+
+	// Vulnerable: form value copied into a buffer that is then executed
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+func handler(db *sql.DB, r *http.Request) {
+	src := []byte(r.FormValue("filter"))
+	buf := make([]byte, 256)
+	n := copy(buf, src)
+	db.Exec("DELETE FROM users WHERE " + string(buf[:n]))
+}
+`}, 1, gosec.NewConfig()},
+
+	// Vulnerable: form value copied into the tail of a buffer
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+func handler(db *sql.DB, r *http.Request) {
+	prefix := "DELETE FROM users WHERE "
+	buf := make([]byte, 256)
+	n := copy(buf, prefix)
+	n += copy(buf[n:], r.FormValue("filter"))
+	db.Exec(string(buf[:n]))
+}
+`}, 1, gosec.NewConfig()},
+
+	// Safe: buffer copied from a constant slice
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+)
+
+func handler(db *sql.DB) {
+	src := []byte("DELETE FROM sessions WHERE expired = 1")
+	buf := make([]byte, len(src))
+	copy(buf, src)
+	db.Exec(string(buf))
+}
+`}, 0, gosec.NewConfig()},
+
+	// Vulnerable: %d does not drop a string argument, it prints it as %!d(string=...)
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+)
+
+func handler(db *sql.DB, r *http.Request) {
+	query := fmt.Sprintf("SELECT * FROM users WHERE id = %d", r.FormValue("id"))
+	db.Query(query)
+}
+`}, 1, gosec.NewConfig()},
+
+	// Vulnerable: %q quoting is not SQL escaping
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+)
+
+func handler(db *sql.DB, r *http.Request) {
+	name := fmt.Sprintf("%q", r.FormValue("name"))
+	db.Query("SELECT * FROM users WHERE name = " + name)
+}
+`}, 1, gosec.NewConfig()},
+
+	// Safe: all formatted arguments are constants
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+func handler(db *sql.DB) {
+	query := fmt.Sprintf("SELECT * FROM %s LIMIT %d", "users", 10)
+	db.Query(query)
+}
+`}, 0, gosec.NewConfig()},
+
+	// Vulnerable: string arm of a type switch over a tainted interface value
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+func handler(db *sql.DB, r *http.Request) {
+	var value interface{} = r.FormValue("name")
+	query := "SELECT * FROM users"
+	switch v := value.(type) {
+	case string:
+		query += " WHERE name = '" + v + "'"
+	case int:
+		query += " LIMIT 10"
+	}
+	db.Query(query)
+}
+`}, 1, gosec.NewConfig()},
+
+	// Safe: only constant arms of the type switch reach the query
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+func handler(db *sql.DB, r *http.Request) {
+	var value interface{} = r.FormValue("name")
+	query := "SELECT * FROM users"
+	switch value.(type) {
+	case string:
+		query += " ORDER BY name"
+	case int:
+		query += " ORDER BY id"
+	}
+	db.Query(query)
+}
+`}, 0, gosec.NewConfig()},
+
+	// Vulnerable: promoted field of an embedded struct set from a form value
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+type Filter struct {
+	Name string
+}
+
+type Search struct {
+	Filter
+	Limit int
+}
+
+func handler(db *sql.DB, r *http.Request) {
+	var search Search
+	search.Name = r.FormValue("name")
+	db.Query("SELECT * FROM users WHERE name = '" + search.Name + "'")
+}
+`}, 1, gosec.NewConfig()},
+
+	// Vulnerable: promoted field of an embedded pointer set from a form value
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+type Filter struct {
+	Name string
+	Role string
+}
+
+type Search struct {
+	*Filter
+}
+
+func handler(db *sql.DB, r *http.Request) {
+	search := Search{Filter: &Filter{Role: "user"}}
+	search.Name = r.FormValue("name")
+	db.Query("SELECT * FROM users WHERE name = '" + search.Name + "'")
+}
+`}, 1, gosec.NewConfig()},
+
+	// Safe: only a sibling field of the embedded struct is tainted
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+type Filter struct {
+	Name string
+	Role string
+}
+
+type Search struct {
+	*Filter
+}
+
+func handler(db *sql.DB, r *http.Request) {
+	search := Search{Filter: &Filter{Name: "admin"}}
+	search.Role = r.FormValue("role")
+	db.Query("SELECT * FROM users WHERE name = '" + search.Name + "'")
+}
+`}, 0, gosec.NewConfig()},
+
+	// Vulnerable: tainted query passed as an argument to a goroutine literal
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+func handler(db *sql.DB, r *http.Request) {
+	query := "SELECT * FROM users WHERE name = '" + r.FormValue("name") + "'"
+	go func(q string) {
+		db.Query(q)
+	}(query)
+}
+`}, 1, gosec.NewConfig()},
+
+	// Vulnerable: tainted query passed as an argument to a named goroutine
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+func worker(db *sql.DB, q string) {
+	db.Query(q)
+}
+
+func handler(db *sql.DB, r *http.Request) {
+	go worker(db, "SELECT * FROM users WHERE name = '"+r.FormValue("name")+"'")
+}
+`}, 1, gosec.NewConfig()},
+
+	// Safe: constant query passed as an argument to a goroutine literal
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+func handler(db *sql.DB, r *http.Request) {
+	_ = r.FormValue("name")
+	go func(q string) {
+		db.Query(q)
+	}("SELECT * FROM users")
+}
+`}, 0, gosec.NewConfig()},
+
+	// Vulnerable: deferred exec of a query built from a form value
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+func handler(db *sql.DB, r *http.Request) {
+	defer db.Exec("DELETE FROM sessions WHERE id = " + r.FormValue("id"))
+}
+`}, 1, gosec.NewConfig()},
+
+	// Safe: deferred exec of a parameterized query
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+func handler(db *sql.DB, r *http.Request) {
+	defer db.Exec("DELETE FROM sessions WHERE id = ?", r.FormValue("id"))
+}
+`}, 0, gosec.NewConfig()},
+
+	// Vulnerable: getter of a tainted struct bound as a method value
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+type Filter struct {
+	name string
+}
+
+func NewFilter(name string) *Filter {
+	return &Filter{name: name}
+}
+
+func (f *Filter) Query() string {
+	return "SELECT * FROM users WHERE name = '" + f.name + "'"
+}
+
+func handler(db *sql.DB, r *http.Request) {
+	filter := NewFilter(r.FormValue("name"))
+	query := filter.Query
+	db.Query(query())
+}
+`}, 1, gosec.NewConfig()},
+
+	// Safe: getter of a struct built from a constant bound as a method value
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+)
+
+type Filter struct {
+	name string
+}
+
+func NewFilter(name string) *Filter {
+	return &Filter{name: name}
+}
+
+func (f *Filter) Query() string {
+	return "SELECT * FROM users WHERE name = '" + f.name + "'"
+}
+
+func handler(db *sql.DB) {
+	filter := NewFilter("admin")
+	query := filter.Query
+	db.Query(query())
+}
+`}, 0, gosec.NewConfig()},
+
+	// Safe: query built around a helper returning a constant, whatever its argument
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+const usersTable = "users"
+
+func tableFor(kind string) string {
+	return usersTable
+}
+
+func buildQuery(kind string) string {
+	return "SELECT * FROM " + tableFor(kind) + " LIMIT 10"
+}
+
+func handler(db *sql.DB, r *http.Request) {
+	db.Query(buildQuery(r.FormValue("kind")))
+}
+`}, 0, gosec.NewConfig()},
+
+	// Vulnerable: form value cached in a sync.Map, loaded and concatenated
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+	"sync"
+)
+
+var names sync.Map
+
+func remember(r *http.Request) {
+	names.Store(r.FormValue("id"), r.FormValue("name"))
+}
+
+func handler(db *sql.DB, r *http.Request) {
+	remember(r)
+	if v, ok := names.Load(r.FormValue("id")); ok {
+		db.Query("SELECT * FROM users WHERE name = '" + v.(string) + "'")
+	}
+}
+`}, 1, gosec.NewConfig()},
+
+	// Safe: sync.Map holding only constants, whatever the key looked up
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+	"sync"
+)
+
+var tables sync.Map
+
+func init() {
+	tables.Store("user", "users")
+	tables.Store("order", "orders")
+}
+
+func handler(db *sql.DB, r *http.Request) {
+	if v, ok := tables.Load(r.FormValue("kind")); ok {
+		db.Query("SELECT * FROM " + v.(string))
+	}
+}
+`}, 0, gosec.NewConfig()},
+
+	// Tainted value written through a pointer to an array taints the whole
+	// array, so another element read back is tainted
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+)
+
+func handler(db *sql.DB, r *http.Request) {
+	arr := &[3]string{}
+	i, _ := strconv.Atoi(r.FormValue("i"))
+	arr[i] = r.FormValue("name")
+	db.Query("SELECT * FROM users WHERE name = '" + arr[0] + "'")
+}
+`}, 1, gosec.NewConfig()},
+
+	// Constant values written through a pointer to an array, under a branch on
+	// request data
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+func handler(db *sql.DB, r *http.Request) {
+	arr := &[3]string{}
+	arr[1] = "users"
+	if r.FormValue("archived") != "" {
+		arr[1] = "archived_users"
+	}
+	db.Query("SELECT * FROM " + arr[1])
+}
+`}, 0, gosec.NewConfig()},
+
+	// Interface method call with several implementations: every implementation
+	// is a possible callee, so the one reaching a sink is reported even when
+	// another one is used at run time
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+type Store interface {
+	Save(name string) error
+}
+
+type sqlStore struct {
+	db *sql.DB
+}
+
+func (s *sqlStore) Save(name string) error {
+	_, err := s.db.Exec("INSERT INTO users (name) VALUES ('" + name + "')")
+	return err
+}
+
+type memStore struct {
+	names []string
+}
+
+func (m *memStore) Save(name string) error {
+	m.names = append(m.names, name)
+	return nil
+}
+
+func newSQLStore(db *sql.DB) Store {
+	return &sqlStore{db: db}
+}
+
+func handler(r *http.Request) {
+	var store Store = &memStore{}
+	_ = store.Save(r.FormValue("name"))
+}
+`}, 1, gosec.NewConfig()},
+	// Struct decoded from the request body is tainted as a whole
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+)
+
+type createUser struct {
+	Name string
+	Age  int
+}
+
+func handler(db *sql.DB, r *http.Request) {
+	var in createUser
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		return
+	}
+	db.Exec("INSERT INTO users (name) VALUES ('" + in.Name + "')")
+}
+`}, 1, gosec.NewConfig()},
+	// Struct decoded from a constant document is not tainted
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+)
+
+type createUser struct {
+	Name string
+	Age  int
+}
+
+var defaults = []byte(` + "`" + `{"Name": "admin", "Age": 42}` + "`" + `)
+
+func seed(db *sql.DB) {
+	var in createUser
+	if err := json.Unmarshal(defaults, &in); err != nil {
+		return
+	}
+	db.Exec("INSERT INTO users (name) VALUES ('" + in.Name + "')")
+}
+`}, 0, gosec.NewConfig()},
+	// JSON encoding of a struct with a tainted field is tainted
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+)
+
+type auditEntry struct {
+	Action string ` + "`" + `json:"action"` + "`" + `
+	User   string ` + "`" + `json:"user"` + "`" + `
+}
+
+func handler(db *sql.DB, r *http.Request) {
+	entry := auditEntry{Action: "login", User: r.FormValue("user")}
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	db.Exec("INSERT INTO audit (payload) VALUES ('" + string(b) + "')")
+}
+`}, 1, gosec.NewConfig()},
+	// JSON encoding of a constant struct is not tainted
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+)
+
+type auditEntry struct {
+	Action string ` + "`" + `json:"action"` + "`" + `
+	User   string ` + "`" + `json:"user"` + "`" + `
+}
+
+func seed(db *sql.DB) {
+	entry := &auditEntry{Action: "seed", User: "system"}
+	b, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return
+	}
+	db.Exec("INSERT INTO audit (payload) VALUES ('" + string(b) + "')")
+}
+`}, 0, gosec.NewConfig()},
+	// Request wrapped in a struct by middleware, form value read from the field
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+type requestContext struct {
+	db  *sql.DB
+	req *http.Request
+}
+
+func (c *requestContext) lookup() {
+	name := c.req.FormValue("name")
+	rows, err := c.db.Query("SELECT * FROM users WHERE name = '" + name + "'")
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+}
+
+func wrap(db *sql.DB, h func(*requestContext)) http.HandlerFunc {
+	return func(w http.ResponseWriter, request *http.Request) {
+		h(&requestContext{db: db, req: request})
+	}
+}
+
+func main() {
+	var db *sql.DB
+	http.Handle("/users", wrap(db, (*requestContext).lookup))
+}
+`}, 1, gosec.NewConfig()},
+	// Wrapped request built locally from a constant URL is not tainted
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+type requestContext struct {
+	db  *sql.DB
+	req *http.Request
+}
+
+func (c *requestContext) lookup() {
+	rows, err := c.db.Query("SELECT * FROM users WHERE name = '" + c.req.FormValue("name") + "'")
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+}
+
+func seed(db *sql.DB) {
+	req, err := http.NewRequest(http.MethodGet, "http://localhost/?name=admin", nil)
+	if err != nil {
+		return
+	}
+	c := &requestContext{db: db, req: req}
+	c.lookup()
+}
+`}, 0, gosec.NewConfig()},
+	// Sort column concatenated into an ORDER BY clause, reported with the
+	// sql-clause subcategory
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+func handler(db *sql.DB, r *http.Request) {
+	rows, err := db.Query("SELECT id, name FROM users ORDER BY " + r.URL.Query().Get("sort"))
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+}
+`}, 1, gosec.NewConfig()},
+	// Sort column appended to the query with += before the sink, reported
+	// with the sql-clause subcategory
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+func handler(db *sql.DB, r *http.Request) {
+	query := "SELECT id, name FROM users"
+	if r.URL.Query().Has("sort") {
+		query += " ORDER BY " + r.URL.Query().Get("sort")
+	}
+	rows, err := db.Query(query)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+}
+`}, 1, gosec.NewConfig()},
+	// Value concatenated into a WHERE clause, reported without a subcategory
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+func handler(db *sql.DB, r *http.Request) {
+	rows, err := db.Query("SELECT id, name FROM users WHERE name = '" + r.URL.Query().Get("name") + "'")
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+}
+`}, 1, gosec.NewConfig()},
+	// Tainted value swapped into the queried variable
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+func handler(db *sql.DB, r *http.Request) {
+	a, b := "admin", r.FormValue("name")
+	a, b = b, a
+	_ = b
+	rows, err := db.Query("SELECT * FROM users WHERE name = '" + a + "'")
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+}
+`}, 1, gosec.NewConfig()},
+	// Swap moves the tainted value out of the queried variable
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+func handler(db *sql.DB, r *http.Request) {
+	a, b := r.FormValue("name"), "admin"
+	a, b = b, a
+	_ = b
+	rows, err := db.Query("SELECT * FROM users WHERE name = '" + a + "'")
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+}
+`}, 0, gosec.NewConfig()},
+	// Tainted struct field swapped into the queried field
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+type pair struct {
+	x, y string
+}
+
+func handler(db *sql.DB, r *http.Request) {
+	p := &pair{x: "admin", y: r.FormValue("name")}
+	p.x, p.y = p.y, p.x
+	rows, err := db.Query("SELECT * FROM users WHERE name = '" + p.x + "'")
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+}
+`}, 1, gosec.NewConfig()},
+	// Swap moves the tainted value out of the queried field
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+type pair struct {
+	x, y string
+}
+
+func handler(db *sql.DB, r *http.Request) {
+	p := &pair{x: r.FormValue("name"), y: "admin"}
+	p.x, p.y = p.y, p.x
+	rows, err := db.Query("SELECT * FROM users WHERE name = '" + p.x + "'")
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+}
+`}, 0, gosec.NewConfig()},
+	// Tainted value converted to bytes, sliced and converted back
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+func handler(db *sql.DB, r *http.Request) {
+	rows, err := db.Query("SELECT * FROM users WHERE name = '" + string([]byte(r.FormValue("name"))[1:]) + "'")
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+}
+`}, 1, gosec.NewConfig()},
+	// Tainted value converted to runes, sliced and converted back
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+func handler(db *sql.DB, r *http.Request) {
+	rows, err := db.Query("SELECT * FROM users WHERE name = '" + string([]rune(r.FormValue("name"))[1:3]) + "'")
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+}
+`}, 1, gosec.NewConfig()},
+	// Constant converted to bytes, sliced and converted back
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+func handler(db *sql.DB, r *http.Request) {
+	rows, err := db.Query("SELECT * FROM users WHERE name = '" + string([]byte("admin")[1:]) + "'")
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+}
+`}, 0, gosec.NewConfig()},
+	// Tainted buffer put in a sync.Pool and got back into a query
+	{[]string{`
+package main
+
+import (
+	"bytes"
+	"database/sql"
+	"net/http"
+	"sync"
+)
+
+var pool = sync.Pool{New: func() any { return new(bytes.Buffer) }}
+
+func record(r *http.Request) {
+	buf := pool.Get().(*bytes.Buffer)
+	buf.WriteString(r.FormValue("name"))
+	pool.Put(buf)
+}
+
+func lookup(db *sql.DB) {
+	buf := pool.Get().(*bytes.Buffer)
+	defer pool.Put(buf)
+	rows, err := db.Query("SELECT * FROM users WHERE name = '" + buf.String() + "'")
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+}
+`}, 1, gosec.NewConfig()},
+	// sync.Pool only holding constant data
+	{[]string{`
+package main
+
+import (
+	"bytes"
+	"database/sql"
+	"net/http"
+	"sync"
+)
+
+var pool = sync.Pool{New: func() any { return new(bytes.Buffer) }}
+
+func record(r *http.Request) {
+	buf := pool.Get().(*bytes.Buffer)
+	buf.WriteString("admin")
+	pool.Put(buf)
+}
+
+func lookup(db *sql.DB) {
+	buf := pool.Get().(*bytes.Buffer)
+	defer pool.Put(buf)
+	rows, err := db.Query("SELECT * FROM users WHERE name = '" + buf.String() + "'")
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+}
+`}, 0, gosec.NewConfig()},
+	// Setter method storing a tainted value in a receiver field read by a builder method
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+type queryBuilder struct {
+	table string
+}
+
+func (q *queryBuilder) SetTable(table string) {
+	q.table = table
+}
+
+func (q *queryBuilder) Build() string {
+	return "SELECT * FROM " + q.table
+}
+
+func handler(db *sql.DB, r *http.Request) {
+	qb := &queryBuilder{}
+	qb.SetTable(r.FormValue("table"))
+	rows, err := db.Query(qb.Build())
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+}
+`}, 1, gosec.NewConfig()},
+	// Setter method storing a constant in a receiver field read by a builder method
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+type queryBuilder struct {
+	table string
+}
+
+func (q *queryBuilder) SetTable(table string) {
+	q.table = table
+}
+
+func (q *queryBuilder) Build() string {
+	return "SELECT * FROM " + q.table
+}
+
+func handler(db *sql.DB, r *http.Request) {
+	qb := &queryBuilder{}
+	qb.SetTable("users")
+	rows, err := db.Query(qb.Build())
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+}
+`}, 0, gosec.NewConfig()},
+	// gRPC request message getter concatenated into a query
+	{[]string{`
+package main
+
+import (
+	"context"
+	"database/sql"
+)
+
+// GetUserRequest stubs a message generated by protoc-gen-go
+type GetUserRequest struct {
+	Name string
+}
+
+func (x *GetUserRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (*GetUserRequest) ProtoMessage() {}
+
+type userServer struct {
+	db *sql.DB
+}
+
+func (s *userServer) GetUser(ctx context.Context, req *GetUserRequest) error {
+	rows, err := s.db.QueryContext(ctx, "SELECT * FROM users WHERE name = '"+req.GetName()+"'")
+	if err != nil {
+		return err
+	}
+	return rows.Close()
+}
+`}, 1, gosec.NewConfig()},
+	// gRPC request message getter passed as a query parameter
+	{[]string{`
+package main
+
+import (
+	"context"
+	"database/sql"
+)
+
+// GetUserRequest stubs a message generated by protoc-gen-go
+type GetUserRequest struct {
+	Name string
+}
+
+func (x *GetUserRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (*GetUserRequest) ProtoMessage() {}
+
+type userServer struct {
+	db *sql.DB
+}
+
+func (s *userServer) GetUser(ctx context.Context, req *GetUserRequest) error {
+	rows, err := s.db.QueryContext(ctx, "SELECT * FROM users WHERE name = ?", req.GetName())
+	if err != nil {
+		return err
+	}
+	return rows.Close()
+}
+`}, 0, gosec.NewConfig()},
+	// Tainted value captured by a closure scheduled with time.AfterFunc
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+	"time"
+)
+
+func handler(db *sql.DB, r *http.Request) {
+	name := r.FormValue("name")
+	time.AfterFunc(time.Minute, func() {
+		rows, err := db.Query("DELETE FROM sessions WHERE user = '" + name + "'")
+		if err == nil {
+			rows.Close()
+		}
+	})
+}
+`}, 1, gosec.NewConfig()},
+	// Constant captured by a closure scheduled with time.AfterFunc
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+	"time"
+)
+
+func handler(db *sql.DB, r *http.Request) {
+	_ = r.FormValue("name")
+	name := "expired"
+	time.AfterFunc(time.Minute, func() {
+		rows, err := db.Query("DELETE FROM sessions WHERE user = '" + name + "'")
+		if err == nil {
+			rows.Close()
+		}
+	})
+}
 `}, 0, gosec.NewConfig()},
 }
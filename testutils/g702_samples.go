@@ -42,5 +42,61 @@ func safeCommand() {
 	// Safe - no user input
 	exec.Command("ls", "-la").Run()
 }
+`}, 0, gosec.NewConfig()},
+
+	// Shell command taken from a form value
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"os/exec"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	exec.Command("sh", "-c", r.FormValue("cmd")).Run()
+}
+`}, 1, gosec.NewConfig()},
+
+	// Tainted arguments through CommandContext and syscall.Exec
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	exec.CommandContext(r.Context(), "git", "checkout", r.URL.Query().Get("branch")).Run()
+	syscall.Exec("/bin/sh", []string{"sh", "-c", r.FormValue("cmd")}, os.Environ())
+}
+`}, 2, gosec.NewConfig()},
+
+	// Safe: subcommand chosen between constants, request context only used for cancellation
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"os/exec"
+	"strconv"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	sub := "status"
+	if r.FormValue("verbose") == "1" {
+		sub = "log"
+	}
+	exec.CommandContext(r.Context(), "git", sub).Run()
+
+	n, err := strconv.Atoi(r.FormValue("n"))
+	if err != nil {
+		return
+	}
+	exec.Command("git", "log", "-n", strconv.Itoa(n)).Run()
+}
 `}, 0, gosec.NewConfig()},
 }
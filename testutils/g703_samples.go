@@ -184,5 +184,102 @@ import (
 func handler(w http.ResponseWriter, r *http.Request) {
 	http.ServeFile(w, r, "static/index.html")
 }
+`}, 0, gosec.NewConfig()},
+	// True positive: query parameter opened directly
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"os"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	f, err := os.Open(r.URL.Query().Get("f"))
+	if err != nil {
+		return
+	}
+	defer f.Close()
+}
+`}, 1, gosec.NewConfig()},
+	// True negative: joined path validated against the base directory
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const baseDir = "/srv/files"
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	path := filepath.Join(baseDir, r.URL.Query().Get("f"))
+	if !strings.HasPrefix(path, baseDir+string(filepath.Separator)) {
+		http.Error(w, "invalid path", http.StatusBadRequest)
+		return
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return
+	}
+	_, _ = w.Write(data)
+}
+`}, 0, gosec.NewConfig()},
+	// True negative: joined path rejected when it still contains ".."
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	path := filepath.Join("uploads", r.FormValue("name"))
+	if strings.Contains(path, "..") {
+		http.Error(w, "invalid path", http.StatusBadRequest)
+		return
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+}
+`}, 0, gosec.NewConfig()},
+	// True positive: prefix check on the raw value does not resolve ".."
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"os"
+	"strings"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	path := r.FormValue("path")
+	if strings.HasPrefix(path, "/srv/files/") {
+		_, _ = os.Open(path)
+	}
+}
+`}, 1, gosec.NewConfig()},
+	// True negative: user input only written as file contents
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"os"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	_ = os.WriteFile("/var/log/app/last-comment.txt", []byte(r.FormValue("comment")), 0o600)
+}
 `}, 0, gosec.NewConfig()},
 }
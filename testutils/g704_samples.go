@@ -139,7 +139,9 @@ func doImport(httpDoer HTTPDoer) error {
 	return nil
 }
 `}, 0, gosec.NewConfig()},
-	// Issue #1629 counterpart: URL from os.Getenv through wrapper MUST still fire.
+	// Issue #1629 counterpart: URL from os.Getenv through wrapper MUST still fire,
+	// both where the request is built and where the wrapper sends it, as
+	// without the wrapper.
 	{[]string{`
 package main
 
@@ -175,5 +177,88 @@ func doImport(httpDoer HTTPDoer) error {
 	defer resp.Body.Close()
 	return nil
 }
+`}, 2, gosec.NewConfig()},
+	// True positive: form value fetched directly
+	{[]string{`
+package main
+
+import (
+	"net/http"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	resp, err := http.Get(r.FormValue("url"))
+	if err != nil {
+		return
+	}
+	defer resp.Body.Close()
+}
+`}, 1, gosec.NewConfig()},
+	// True negative: constant URL
+	{[]string{`
+package main
+
+import (
+	"net/http"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	resp, err := http.Get("https://api.example.com/status")
+	if err != nil {
+		return
+	}
+	defer resp.Body.Close()
+}
+`}, 0, gosec.NewConfig()},
+	// True negative: parsed URL whose host is checked against a fixed allowlist
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"net/url"
+)
+
+var allowedHosts = map[string]bool{
+	"api.example.com":    true,
+	"images.example.com": true,
+}
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	u, err := url.Parse(r.FormValue("url"))
+	if err != nil || !allowedHosts[u.Hostname()] {
+		http.Error(w, "host not allowed", http.StatusBadRequest)
+		return
+	}
+	client := &http.Client{}
+	resp, err := client.Get(u.String())
+	if err != nil {
+		return
+	}
+	defer resp.Body.Close()
+}
+`}, 0, gosec.NewConfig()},
+	// True positive: host allowlist does not cover a different value
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"net/url"
+)
+
+var allowedHosts = map[string]bool{"api.example.com": true}
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	u, err := url.Parse(r.FormValue("url"))
+	if err != nil || !allowedHosts[u.Host] {
+		return
+	}
+	resp, err := http.Get(r.FormValue("next"))
+	if err != nil {
+		return
+	}
+	defer resp.Body.Close()
+}
 `}, 1, gosec.NewConfig()},
 }
@@ -194,4 +194,102 @@ func main() {
 	_ = http.ListenAndServe(":8080", nil)
 }
 `}, 1, gosec.NewConfig()},
+	// True positive: text/template renders request data unescaped into the response
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"text/template"
+)
+
+var tmpl = template.Must(template.New("page").Parse(` + "`<h1>Hello {{.}}</h1>`" + `))
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	name := r.FormValue("name")
+	tmpl.Execute(w, name)
+}
+`}, 1, gosec.NewConfig()},
+	// True positive: ExecuteTemplate with tainted data to the response
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"text/template"
+)
+
+var tmpl = template.Must(template.New("").Parse(` + "`{{define \"greeting\"}}Hello {{.}}{{end}}`" + `))
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	name := r.FormValue("name")
+	tmpl.ExecuteTemplate(w, "greeting", name)
+}
+`}, 1, gosec.NewConfig()},
+	// True negative: text/template data escaped before rendering
+	{[]string{`
+package main
+
+import (
+	"html"
+	"net/http"
+	"text/template"
+)
+
+var tmpl = template.Must(template.New("page").Parse(` + "`<h1>Hello {{.}}</h1>`" + `))
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	safe := html.EscapeString(r.FormValue("name"))
+	tmpl.Execute(w, safe)
+}
+`}, 0, gosec.NewConfig()},
+
+	// True positive: request data converted to template.HTML is rendered unescaped
+	{[]string{`
+package main
+
+import (
+	"html/template"
+	"net/http"
+)
+
+var page = template.Must(template.New("page").Parse(` + "`<div>{{.}}</div>`" + `))
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	page.Execute(w, template.HTML(r.FormValue("bio")))
+}
+`}, 1, gosec.NewConfig()},
+
+	// True positive: request data concatenated into template.JS
+	{[]string{`
+package main
+
+import (
+	"html/template"
+	"net/http"
+)
+
+var page = template.Must(template.New("page").Parse(` + "`<script>{{.}}</script>`" + `))
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	script := template.JS("var user = '" + r.FormValue("user") + "';")
+	page.Execute(w, script)
+}
+`}, 1, gosec.NewConfig()},
+
+	// True negative: constant converted to template.HTML
+	{[]string{`
+package main
+
+import (
+	"html/template"
+	"net/http"
+)
+
+var page = template.Must(template.New("page").Parse(` + "`<div>{{.}}</div>`" + `))
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	page.Execute(w, template.HTML("<b>Welcome</b>"))
+}
+`}, 0, gosec.NewConfig()},
 }
@@ -134,5 +134,104 @@ func handler(r *http.Request) {
 	num, _ := strconv.Atoi(id)
 	log.Printf("Processing ID: %d", num)
 }
+`}, 0, gosec.NewConfig()},
+	// True positive: form value formatted into a log line
+	{[]string{`
+package main
+
+import (
+	"log"
+	"net/http"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	log.Printf("user=%s", r.FormValue("u"))
+}
+`}, 1, gosec.NewConfig()},
+	// True negative: strconv.Quote escapes CR and LF
+	{[]string{`
+package main
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	log.Printf("user=%s", strconv.Quote(r.FormValue("u")))
+}
+`}, 0, gosec.NewConfig()},
+	// True positive: tainted value logged through a *log.Logger
+	{[]string{`
+package main
+
+import (
+	"log"
+	"net/http"
+	"os"
+)
+
+var logger = log.New(os.Stderr, "auth ", log.LstdFlags)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	logger.Printf("failed login for %s", r.FormValue("user"))
+}
+`}, 1, gosec.NewConfig()},
+	// True positive: tainted message through a *slog.Logger, attributes are escaped
+	{[]string{`
+package main
+
+import (
+	"log/slog"
+	"net/http"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	logger := slog.Default()
+	logger.InfoContext(r.Context(), "login", "user", r.FormValue("user"))
+	logger.Warn("failed login for " + r.FormValue("user"))
+}
+`}, 1, gosec.NewConfig()},
+	// True positive: error built from request data with fmt.Errorf and logged
+	// through Error(), also after being returned and wrapped
+	{[]string{`
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+)
+
+func validate(name string) error {
+	if name == "" {
+		return nil
+	}
+	return fmt.Errorf("invalid name %s", name)
+}
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	err := fmt.Errorf("lookup: %w", validate(r.FormValue("user")))
+	log.Print("failed: " + err.Error())
+}
+`}, 1, gosec.NewConfig()},
+	// True negative: constant error logged through Error()
+	{[]string{`
+package main
+
+import (
+	"errors"
+	"log"
+	"net/http"
+)
+
+var errNotFound = errors.New("user not found")
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	if r.FormValue("user") == "" {
+		log.Print(errNotFound.Error())
+	}
+}
 `}, 0, gosec.NewConfig()},
 }
@@ -64,5 +64,74 @@ func handler(w http.ResponseWriter, r *http.Request) {
 	}
 	http.Redirect(w, r, fmt.Sprintf("/users/%d", id), http.StatusFound)
 }
+`}, 0, gosec.NewConfig()},
+
+	// Positive: form value set as the Location header of the response.
+	{[]string{`
+package main
+
+import (
+	"net/http"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Location", r.FormValue("next"))
+	w.WriteHeader(http.StatusFound)
+}
+`}, 1, gosec.NewConfig()},
+
+	// Negative: form value set in a header other than Location.
+	{[]string{`
+package main
+
+import (
+	"net/http"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("X-Request-Id", r.FormValue("id"))
+	http.Redirect(w, r, "/dashboard", http.StatusFound)
+}
+`}, 0, gosec.NewConfig()},
+
+	// Negative: target validated with url.Parse to be a path on the same site.
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"net/url"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	next := r.FormValue("next")
+	u, err := url.Parse(next)
+	if err != nil || u.Host != "" {
+		http.Error(w, "invalid redirect", http.StatusBadRequest)
+		return
+	}
+	http.Redirect(w, r, next, http.StatusFound)
+}
+`}, 0, gosec.NewConfig()},
+
+	// Negative: host of the parsed target checked against an allowlist.
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"net/url"
+)
+
+var allowedHosts = map[string]bool{"example.com": true}
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	u, err := url.Parse(r.FormValue("next"))
+	if err != nil || !allowedHosts[u.Hostname()] {
+		http.Error(w, "invalid redirect", http.StatusBadRequest)
+		return
+	}
+	http.Redirect(w, r, u.String(), http.StatusFound)
+}
 `}, 0, gosec.NewConfig()},
 }
@@ -0,0 +1,97 @@
+package testutils
+
+import "github.com/securego/gosec/v2"
+
+// SampleCodeG712 - Regular expression injection via taint analysis
+var SampleCodeG712 = []CodeSample{
+	// Positive: query parameter compiled as a pattern.
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"regexp"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	re := regexp.MustCompile(r.FormValue("pat"))
+	if re.MatchString("admin") {
+		w.WriteHeader(http.StatusForbidden)
+	}
+}
+`}, 1, gosec.NewConfig()},
+
+	// Positive: pattern built around user input and matched directly.
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"regexp"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	ok, err := regexp.MatchString("^"+r.URL.Query().Get("prefix")+".*$", "report.csv")
+	if err != nil || !ok {
+		http.NotFound(w, r)
+	}
+}
+`}, 1, gosec.NewConfig()},
+
+	// Negative: constant pattern.
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"regexp"
+)
+
+var idPattern = regexp.MustCompile("^[a-z0-9-]+$")
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	re, err := regexp.Compile("^[a-z]+$")
+	if err != nil {
+		return
+	}
+	_ = re
+	_ = idPattern
+}
+`}, 0, gosec.NewConfig()},
+
+	// Negative: user input is the text matched against a constant pattern.
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"regexp"
+)
+
+var idPattern = regexp.MustCompile("^[a-z0-9-]+$")
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	if !idPattern.MatchString(r.FormValue("id")) {
+		http.Error(w, "bad id", http.StatusBadRequest)
+	}
+}
+`}, 0, gosec.NewConfig()},
+
+	// Negative: user input quoted before it is compiled.
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"regexp"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	re, err := regexp.Compile("^" + regexp.QuoteMeta(r.FormValue("name")) + "$")
+	if err != nil {
+		return
+	}
+	_ = re.MatchString("alice")
+}
+`}, 0, gosec.NewConfig()},
+}
@@ -0,0 +1,122 @@
+package testutils
+
+import "github.com/securego/gosec/v2"
+
+// SampleCodeG714 - Environment variable injection via taint analysis
+var SampleCodeG714 = []CodeSample{
+	// Positive: form value set as an environment variable of the process.
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"os"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	if err := os.Setenv("X", r.FormValue("v")); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+`}, 1, gosec.NewConfig()},
+
+	// Negative: constant environment value.
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"os"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	if err := os.Setenv("X", "enabled"); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+`}, 0, gosec.NewConfig()},
+
+	// Positive: query parameter appended to the environment of a child
+	// process.
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"os"
+	"os/exec"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	cmd := exec.Command("git", "fetch")
+	cmd.Env = append(os.Environ(), "GIT_SSH_COMMAND="+r.URL.Query().Get("ssh"))
+	if err := cmd.Run(); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+`}, 1, gosec.NewConfig()},
+
+	// Positive: tainted entry in the Env field of a command literal.
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"os/exec"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	cmd := &exec.Cmd{
+		Path: "/usr/bin/git",
+		Args: []string{"git", "fetch"},
+		Env:  []string{"HOME=" + r.FormValue("home")},
+	}
+	if err := cmd.Run(); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+`}, 1, gosec.NewConfig()},
+
+	// Negative: Env built from constants only.
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"os"
+	"os/exec"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	cmd := exec.Command("git", "fetch")
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	if err := cmd.Run(); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+`}, 0, gosec.NewConfig()},
+
+	// Negative: numeric input parsed before it reaches the environment.
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"os"
+	"strconv"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	n, err := strconv.Atoi(r.FormValue("workers"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	_ = os.Setenv("WORKERS", strconv.Itoa(n))
+}
+`}, 0, gosec.NewConfig()},
+}
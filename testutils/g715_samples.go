@@ -0,0 +1,88 @@
+package testutils
+
+import "github.com/securego/gosec/v2"
+
+// SampleCodeG715 - Format string injection via taint analysis
+var SampleCodeG715 = []CodeSample{
+	// Positive: request value used as the Sprintf format
+	{[]string{`
+package main
+
+import (
+	"fmt"
+	"net/http"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	msg := fmt.Sprintf(r.FormValue("fmt"), "guest")
+	_, _ = w.Write([]byte(msg))
+}
+`}, 1, gosec.NewConfig()},
+
+	// Negative: request value passed as data to a constant format
+	{[]string{`
+package main
+
+import (
+	"fmt"
+	"net/http"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	msg := fmt.Sprintf("%s", r.FormValue("x"))
+	_, _ = w.Write([]byte(msg))
+}
+`}, 0, gosec.NewConfig()},
+
+	// Positive: request value used as the Errorf format
+	{[]string{`
+package main
+
+import (
+	"fmt"
+	"net/http"
+)
+
+func validate(r *http.Request) error {
+	if reason := r.URL.Query().Get("reason"); reason != "" {
+		return fmt.Errorf(reason)
+	}
+	return nil
+}
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	if err := validate(r); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	}
+}
+`}, 1, gosec.NewConfig()},
+
+	// Positive: tainted format after the destination writer
+	{[]string{`
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+func main() {
+	fmt.Fprintf(os.Stdout, os.Getenv("GREETING_FORMAT"), os.Getenv("USER"))
+}
+`}, 1, gosec.NewConfig()},
+
+	// Negative: tainted values passed as Printf and Fprintf data
+	{[]string{`
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+func main() {
+	fmt.Printf("args: %v\n", os.Args[1:])
+	fmt.Fprintf(os.Stderr, "user %q\n", os.Getenv("USER"))
+}
+`}, 0, gosec.NewConfig()},
+}
@@ -0,0 +1,123 @@
+package testutils
+
+import "github.com/securego/gosec/v2"
+
+// SampleCodeG716 - HTTP header injection via taint analysis
+var SampleCodeG716 = []CodeSample{
+	// Positive: query parameter set as a response header value.
+	{[]string{`
+package main
+
+import (
+	"net/http"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("X-Request-Id", r.URL.Query().Get("id"))
+	w.WriteHeader(http.StatusNoContent)
+}
+`}, 1, gosec.NewConfig()},
+
+	// Positive: form value added to a header of an outgoing request.
+	{[]string{`
+package main
+
+import (
+	"net/http"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	req, err := http.NewRequest(http.MethodGet, "https://api.example.com/items", nil)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	req.Header.Add("X-Trace", "trace="+r.FormValue("trace"))
+	_, _ = http.DefaultClient.Do(req)
+}
+`}, 1, gosec.NewConfig()},
+
+	// Negative: constant header value.
+	{[]string{`
+package main
+
+import (
+	"net/http"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusNoContent)
+}
+`}, 0, gosec.NewConfig()},
+
+	// Negative: both CR and LF are stripped before the value is set.
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"strings"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Query().Get("id")
+	id = strings.ReplaceAll(strings.ReplaceAll(id, "\r", ""), "\n", "")
+	w.Header().Set("X-Request-Id", id)
+	w.WriteHeader(http.StatusNoContent)
+}
+`}, 0, gosec.NewConfig()},
+
+	// Positive: only LF is stripped, CR still splits the header.
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"strings"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	id := strings.ReplaceAll(r.URL.Query().Get("id"), "\n", "")
+	w.Header().Set("X-Request-Id", id)
+	w.WriteHeader(http.StatusNoContent)
+}
+`}, 1, gosec.NewConfig()},
+
+	// Positive: the replacement reintroduces a line terminator.
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"strings"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Query().Get("id")
+	id = strings.ReplaceAll(strings.ReplaceAll(id, "\r", "\n"), "\n", "\r\n")
+	w.Header().Set("X-Request-Id", id)
+	w.WriteHeader(http.StatusNoContent)
+}
+`}, 1, gosec.NewConfig()},
+
+	// Negative: numeric value parsed from user input.
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"strconv"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	n, err := strconv.Atoi(r.FormValue("page"))
+	if err != nil {
+		http.Error(w, "bad page", http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("X-Page", strconv.Itoa(n))
+	w.WriteHeader(http.StatusNoContent)
+}
+`}, 0, gosec.NewConfig()},
+}